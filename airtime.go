@@ -0,0 +1,212 @@
+package tvmaze
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrNoAirStamp is returned when an Episode has no air stamp to parse.
+var ErrNoAirStamp = errors.New("episode has no airstamp")
+
+// AirStampTime parses the episode AirStamp, which tvmaze provides in RFC 3339 form.
+func (e Episode) AirStampTime() (time.Time, error) {
+	if e.AirStamp == "" {
+		return time.Time{}, ErrNoAirStamp
+	}
+	return time.Parse(time.RFC3339, e.AirStamp)
+}
+
+// Tonight fetches the episodes of each show in showIDs, and returns those airing
+// today, as given by Client.Now, in loc, sorted by air time.  A nil loc means time.Local.
+// Shows are fetched one at a time, so a long list of shows stays well within the
+// tvmaze rate limit, and ctx is checked between shows.
+func (c *Client) Tonight(ctx context.Context, showIDs []int64, loc *time.Location) ([]Episode, error) {
+	if loc == nil {
+		loc = time.Local
+	}
+	year, month, day := c.now().In(loc).Date()
+
+	var tonight []Episode
+	for _, id := range showIDs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		episodes, err := c.getEpisodes(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		for _, ep := range episodes {
+			airs, err := ep.AirStampTime()
+			if err != nil {
+				continue
+			}
+			y, m, d := airs.In(loc).Date()
+			if y == year && m == month && d == day {
+				tonight = append(tonight, ep)
+			}
+		}
+	}
+
+	sort.SliceStable(tonight, func(i, j int) bool {
+		a, _ := tonight[i].AirStampTime()
+		b, _ := tonight[j].AirStampTime()
+		return a.Before(b)
+	})
+	return tonight, nil
+}
+
+// AirLocation returns the timezone the show airs in, taken from its
+// PrimaryCountry.  It falls back to UTC when tvmaze has no timezone for the show,
+// and also when the timezone can't be loaded, in which case the load error is
+// returned alongside UTC.
+func (s Show) AirLocation() (*time.Location, error) {
+	tz := s.PrimaryCountry().TimeZone
+	if tz == "" {
+		return time.UTC, nil
+	}
+	loc, err := loadLocation(tz)
+	if err != nil {
+		return time.UTC, err
+	}
+	return loc, nil
+}
+
+// locations caches the result of time.LoadLocation by IANA name, since loading
+// parses the zoneinfo every time.
+var locations sync.Map
+
+type location struct {
+	loc *time.Location
+	err error
+}
+
+// loadLocation is time.LoadLocation, cached in locations.
+func loadLocation(name string) (*time.Location, error) {
+	if l, ok := locations.Load(name); ok {
+		return l.(location).loc, l.(location).err
+	}
+	loc, err := time.LoadLocation(name)
+	locations.Store(name, location{loc: loc, err: err})
+	return loc, err
+}
+
+// FilterAiredEpisodes returns the episodes in eps that aired strictly before now.
+// Episodes without a valid AirStamp are dropped.
+func FilterAiredEpisodes(eps []Episode, now time.Time) []Episode {
+	var aired []Episode
+	for _, ep := range eps {
+		airs, err := ep.AirStampTime()
+		if err != nil {
+			continue
+		}
+		if airs.Before(now) {
+			aired = append(aired, ep)
+		}
+	}
+	return aired
+}
+
+// GetEpisodesInRange queries tvmaze for the show's episodes, and returns those
+// airing within [from, to].  Both ends are inclusive, so an episode airing at
+// exactly from or to is kept.  Episodes without a valid AirStamp are dropped.
+func (c *Client) GetEpisodesInRange(showID int64, from, to time.Time) ([]Episode, error) {
+	episodes, err := c.GetEpisodes(showID)
+	if err != nil {
+		return nil, err
+	}
+	return episodesInRange(episodes, from, to), nil
+}
+
+// episodesInRange returns the episodes in eps airing within [from, to].
+func episodesInRange(eps []Episode, from, to time.Time) []Episode {
+	var inRange []Episode
+	for _, ep := range eps {
+		airs, err := ep.AirStampTime()
+		if err != nil {
+			continue
+		}
+		if !airs.Before(from) && !airs.After(to) {
+			inRange = append(inRange, ep)
+		}
+	}
+	return inRange
+}
+
+// TimeUntilAir returns how long from now until the episode airs, which is
+// negative once it has aired.  AirStamp carries the network's UTC offset, so the
+// result is correct whatever now's location.  ErrNoAirStamp is returned for an
+// episode without an air date.
+func (e Episode) TimeUntilAir(now time.Time) (time.Duration, error) {
+	airs, err := e.AirStampTime()
+	if err != nil {
+		return 0, err
+	}
+	return airs.Sub(now), nil
+}
+
+// LatestAiredEpisode returns the episode in eps that aired most recently at or
+// before now, and false if none has.  Episodes without a valid AirStamp are
+// skipped.
+func LatestAiredEpisode(eps []Episode, now time.Time) (Episode, bool) {
+	var (
+		latest     Episode
+		latestAirs time.Time
+		found      bool
+	)
+	for _, ep := range eps {
+		airs, err := ep.AirStampTime()
+		if err != nil || airs.After(now) {
+			continue
+		}
+		if !found || airs.After(latestAirs) {
+			latest, latestAirs, found = ep, airs, true
+		}
+	}
+	return latest, found
+}
+
+// GetAiredEpisodeCount queries tvmaze for the show's episodes, and returns how
+// many aired before now, as FilterAiredEpisodes counts them, out of the total.
+// Episodes without an air date count toward total but never aired.
+func (c *Client) GetAiredEpisodeCount(showID int64, now time.Time) (aired, total int, err error) {
+	episodes, err := c.GetEpisodes(showID)
+	if err != nil {
+		return 0, 0, err
+	}
+	return len(FilterAiredEpisodes(episodes, now)), len(episodes), nil
+}
+
+// HiatusThreshold is the gap between episodes above which AiringGap reports a
+// show as on hiatus.
+var HiatusThreshold = 45 * 24 * time.Hour
+
+// AiringGap finds the last episode in eps to air at or before now and the next
+// to air after it, and returns their air times and the gap between them.
+// onHiatus is set when the gap exceeds HiatusThreshold.  When either episode is
+// missing, as for a show that's ended, or has nothing scheduled yet, that time
+// is zero and the gap is unknown, so gap is 0 and onHiatus false; callers may
+// want to consult the show's Status.  Episodes without a valid AirStamp are
+// skipped.
+func AiringGap(eps []Episode, now time.Time) (lastAired, nextAir time.Time, gap time.Duration, onHiatus bool) {
+	for _, ep := range eps {
+		airs, err := ep.AirStampTime()
+		if err != nil {
+			continue
+		}
+		if !airs.After(now) {
+			if airs.After(lastAired) {
+				lastAired = airs
+			}
+		} else if nextAir.IsZero() || airs.Before(nextAir) {
+			nextAir = airs
+		}
+	}
+	if lastAired.IsZero() || nextAir.IsZero() {
+		return lastAired, nextAir, 0, false
+	}
+	gap = nextAir.Sub(lastAired)
+	return lastAired, nextAir, gap, gap > HiatusThreshold
+}
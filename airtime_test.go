@@ -0,0 +1,96 @@
+package tvmaze
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFilterAiredEpisodes(t *testing.T) {
+	now := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	eps := []Episode{
+		{ID: 1, AirStamp: "2021-05-01T01:00:00+00:00"},
+		{ID: 2, AirStamp: "2021-07-01T01:00:00+00:00"},
+		{ID: 3},
+		{ID: 4, AirStamp: "2021-06-01T00:00:00+00:00"},
+		{ID: 5, AirStamp: "not a date"},
+		{ID: 6, AirStamp: "2021-05-31T23:00:00-04:00"},
+	}
+	aired := FilterAiredEpisodes(eps, now)
+	if len(aired) != 1 || aired[0].ID != 1 {
+		t.Errorf("got aired episodes %v, want only episode 1", aired)
+	}
+}
+
+func TestAiringGap(t *testing.T) {
+	now := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	weekAgo := "2021-05-25T01:00:00+00:00"
+	tests := []struct {
+		name     string
+		eps      []Episode
+		nextAir  string
+		gap      time.Duration
+		onHiatus bool
+	}{
+		{"active", []Episode{
+			{AirStamp: "2021-05-18T01:00:00+00:00"},
+			{AirStamp: weekAgo},
+			{AirStamp: "2021-06-01T01:00:00+00:00"},
+		}, "2021-06-01T01:00:00Z", 7 * 24 * time.Hour, false},
+		{"hiatus", []Episode{
+			{AirStamp: weekAgo},
+			{AirStamp: "2021-09-01T01:00:00+00:00"},
+			{AirStamp: "2021-09-08T01:00:00+00:00"},
+		}, "2021-09-01T01:00:00Z", 99 * 24 * time.Hour, true},
+		{"ended", []Episode{
+			{AirStamp: "2021-05-18T01:00:00+00:00"},
+			{AirStamp: weekAgo},
+			{},
+		}, "", 0, false},
+	}
+	for _, tt := range tests {
+		lastAired, nextAir, gap, onHiatus := AiringGap(tt.eps, now)
+		if got := lastAired.Format(time.RFC3339); got != "2021-05-25T01:00:00Z" {
+			t.Errorf("%s: lastAired = %s, want %s", tt.name, got, weekAgo)
+		}
+		if gap != tt.gap || onHiatus != tt.onHiatus {
+			t.Errorf("%s: gap %s, onHiatus %v, want %s, %v", tt.name, gap, onHiatus, tt.gap, tt.onHiatus)
+		}
+		next := ""
+		if !nextAir.IsZero() {
+			next = nextAir.Format(time.RFC3339)
+		}
+		if next != tt.nextAir {
+			t.Errorf("%s: nextAir = %q, want %q", tt.name, next, tt.nextAir)
+		}
+	}
+}
+
+// benchmarkTZ is the timezone loaded by the AirLocation benchmarks.
+const benchmarkTZ = "America/New_York"
+
+func BenchmarkAirLocation(b *testing.B) {
+	if _, err := time.LoadLocation(benchmarkTZ); err != nil {
+		b.Skip(err)
+	}
+	show := Show{Network: Network{ID: 1, Country: Country{Code: "US", TimeZone: benchmarkTZ}}}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := show.AirLocation(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkLoadLocation is the cost AirLocation's cache saves, for comparison
+// with BenchmarkAirLocation.
+func BenchmarkLoadLocation(b *testing.B) {
+	if _, err := time.LoadLocation(benchmarkTZ); err != nil {
+		b.Skip(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := time.LoadLocation(benchmarkTZ); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package tvmaze
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// BulkError is the error for a single ID in a bulk request.
+type BulkError struct {
+	ID  int64
+	Err error
+}
+
+func (e *BulkError) Error() string {
+	return fmt.Sprintf("id %d: %s", e.ID, e.Err.Error())
+}
+
+// Unwrap returns the error for the ID.
+func (e *BulkError) Unwrap() error {
+	return e.Err
+}
+
+// BulkErrors is the error returned by bulk methods that return a single error,
+// holding a *BulkError for each ID that failed.
+type BulkErrors []error
+
+func (e BulkErrors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+	return fmt.Sprintf("%d requests failed, first: %s", len(e), e[0].Error())
+}
+
+// GetEpisodesByIDs queries tvmaze for each episode in ids, embedding the named
+// resources, e.g. "show" to get each episode's show without a second request.
+// Episodes that could be fetched are returned even when others fail, with a
+// *BulkError for each failure.
+func (c *Client) GetEpisodesByIDs(ids []int64, embeds ...string) (map[int64]EpisodeWithEmbeds, []error) {
+	var mu sync.Mutex
+	episodes := make(map[int64]EpisodeWithEmbeds, len(ids))
+	errs := c.bulk(context.Background(), ids, func(ctx context.Context, id int64) error {
+		episode, err := c.getEpisodeByID(ctx, id, embeds)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		episodes[id] = episode
+		mu.Unlock()
+		return nil
+	})
+	return episodes, errs
+}
+
+// bulk calls fn once for each distinct ID in ids, running up to Concurrency calls
+// at once, and returns a *BulkError for each call that failed.  IDs not yet
+// started when ctx is done fail with ctx.Err().
+func (c *Client) bulk(ctx context.Context, ids []int64, fn func(ctx context.Context, id int64) error) []error {
+	workers := c.Concurrency
+	if workers <= 0 {
+		workers = 4
+	}
+
+	var (
+		mu   sync.Mutex
+		errs []error
+		wg   sync.WaitGroup
+	)
+	todo := make(chan int64)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := range todo {
+				err := ctx.Err()
+				if err == nil {
+					err = fn(ctx, id)
+				}
+				if err != nil {
+					mu.Lock()
+					errs = append(errs, &BulkError{ID: id, Err: err})
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	seen := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			todo <- id
+		}
+	}
+	close(todo)
+	wg.Wait()
+
+	return errs
+}
@@ -0,0 +1,133 @@
+package tvmaze
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	cache "github.com/robfig/go-cache"
+)
+
+// ValidateCacheFile reports whether the cache file at path can be loaded, by
+// loading it into a throwaway cache.  No Client is touched, so it's safe to run
+// against the file of a live client, or from a script before startup.
+func ValidateCacheFile(path string) error {
+	c := cache.New(0, 0)
+	err := c.LoadFile(path)
+	if err != nil {
+		return fmt.Errorf("invalid cache file %s: %w", path, err)
+	}
+	return nil
+}
+
+// saveFile atomically writes the cache to CacheFile, by saving it to a temporary
+// file in the same directory and renaming that over CacheFile.  A new CacheFile
+// gets mode 0644, and an existing one keeps its mode.  Saves are made one at a
+// time, but without holding the lock requests use, so they aren't held up by the
+// disk.  An encode can't be interrupted, so when ctx is done first it's left to
+// finish in the background, still holding up the next save, and its temporary
+// file is removed.
+func (c *Client) saveFile(ctx context.Context) error {
+	st := c.shared()
+	select {
+	case st.saving <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	tmp, err := c.createTemp()
+	if err != nil {
+		<-st.saving
+		return err
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		defer func() { <-st.saving }()
+		err := c.persist(tmp)
+		if err == nil {
+			err = tmp.Sync()
+		}
+		closeErr := tmp.Close()
+		if err == nil {
+			err = closeErr
+		}
+		if err == nil {
+			err = ctx.Err()
+		}
+		if err == nil {
+			err = os.Rename(tmp.Name(), c.CacheFile)
+		}
+		if err != nil {
+			os.Remove(tmp.Name())
+		}
+		done <- err
+	}()
+
+	select {
+	case err = <-done:
+		return err
+	case <-ctx.Done():
+		os.Remove(tmp.Name())
+		return ctx.Err()
+	}
+}
+
+// createTemp creates the temporary file saveFile writes to, with CacheFile's mode,
+// or 0644 when there's no CacheFile yet.
+func (c *Client) createTemp() (*os.File, error) {
+	tmp, err := ioutil.TempFile(filepath.Dir(c.CacheFile), filepath.Base(c.CacheFile)+".tmp")
+	if err != nil {
+		return nil, err
+	}
+	mode := os.FileMode(0644)
+	if fi, err := os.Stat(c.CacheFile); err == nil {
+		mode = fi.Mode()
+	}
+	err = tmp.Chmod(mode)
+	if err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return nil, err
+	}
+	return tmp, nil
+}
+
+// StartAutoSave writes the cache to CacheFile every interval in the background,
+// as WriteCacheContext does, skipping the write when nothing that belongs in the
+// file has been cached since the last one.  It stops when ctx is done, without a
+// final write, so call WriteCache after cancelling to save what's cached since.
+// The returned channel is closed once the background goroutine has stopped.
+// Failed writes are logged to Logger, and retried at the next interval.  An error
+// is returned, and nothing started, if interval isn't positive.
+func (c *Client) StartAutoSave(ctx context.Context, interval time.Duration) (<-chan struct{}, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("auto save interval %s isn't positive", interval)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if atomic.LoadInt32(&c.shared().dirty) == 0 {
+					continue
+				}
+				err := c.WriteCacheContext(ctx)
+				if err != nil && ctx.Err() == nil {
+					c.logger().Printf("auto save %s: %s", c.CacheFile, err)
+				}
+			}
+		}
+	}()
+	return done, nil
+}
@@ -0,0 +1,210 @@
+package tvmaze
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	cache "github.com/robfig/go-cache"
+)
+
+func TestWriteCacheContextCancelled(t *testing.T) {
+	dir := t.TempDir()
+	c := &Client{
+		Cache:     cache.New(time.Hour, 0),
+		CacheFile: filepath.Join(dir, "cache"),
+	}
+	c.Cache.Set("before", []byte("kept"), 0)
+	if err := c.WriteCache(); err != nil {
+		t.Fatal(err)
+	}
+
+	c.Cache.Set("after", []byte("dropped"), 0)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := c.WriteCacheContext(ctx); err != context.Canceled {
+		t.Fatalf("got error %v, want %v", err, context.Canceled)
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Errorf("got %d files, want the cache file alone", len(files))
+	}
+	saved := cache.New(0, 0)
+	if err := saved.LoadFile(c.CacheFile); err != nil {
+		t.Fatal(err)
+	}
+	if _, found := saved.Get("before"); !found {
+		t.Error("previous cache file was lost")
+	}
+	if _, found := saved.Get("after"); found {
+		t.Error("cancelled save replaced the cache file")
+	}
+}
+
+func TestStartAutoSaveRejectsInterval(t *testing.T) {
+	c := &Client{Cache: cache.New(time.Hour, 0)}
+	for _, interval := range []time.Duration{0, -time.Second} {
+		if _, err := c.StartAutoSave(context.Background(), interval); err == nil {
+			t.Errorf("StartAutoSave(%s) = nil error", interval)
+		}
+	}
+}
+
+func TestStartAutoSave(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 1}`))
+	})
+	c.CacheFile = filepath.Join(t.TempDir(), "cache")
+	const interval = 10 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done, err := c.StartAutoSave(ctx, interval)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.GetShowByID(1); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, err := os.Stat(c.CacheFile); err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("dirty cache wasn't written")
+		}
+		time.Sleep(interval)
+	}
+
+	// Wait for any write in progress, then check nothing more is written while
+	// the cache is clean.
+	c.shared().saving <- struct{}{}
+	<-c.shared().saving
+	if err := os.Remove(c.CacheFile); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(10 * interval)
+	if _, err := os.Stat(c.CacheFile); !os.IsNotExist(err) {
+		t.Errorf("clean cache was written: %v", err)
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("done wasn't closed after cancelling")
+	}
+}
+
+func TestDirtyOnlyForPersistedResponses(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	})
+	c.PersistToFile = func(uri *url.URL) bool { return uri.Path != "/updates/shows" }
+	if _, err := c.GetShowUpdates(""); err != nil {
+		t.Fatal(err)
+	}
+	if atomic.LoadInt32(&c.shared().dirty) != 0 {
+		t.Error("response rejected by PersistToFile marked the cache dirty")
+	}
+
+	if _, err := c.GetShowByID(1); err != nil {
+		t.Fatal(err)
+	}
+	if atomic.LoadInt32(&c.shared().dirty) == 0 {
+		t.Error("show response didn't mark the cache dirty")
+	}
+	c.Reset()
+	if atomic.LoadInt32(&c.shared().dirty) != 0 {
+		t.Error("Reset left the cache dirty, so auto save would write it empty")
+	}
+}
+
+// blockingGob blocks saves of a cache holding it until encodeRelease is closed,
+// after signalling encodeStarted.
+type blockingGob struct{}
+
+var encodeStarted, encodeRelease chan struct{}
+
+func (blockingGob) GobEncode() ([]byte, error) {
+	encodeStarted <- struct{}{}
+	<-encodeRelease
+	return []byte{}, nil
+}
+
+func (*blockingGob) GobDecode([]byte) error { return nil }
+
+func TestWriteCacheContextAbandonedEncodeHoldsSave(t *testing.T) {
+	encodeStarted, encodeRelease = make(chan struct{}, 1), make(chan struct{})
+	c := &Client{
+		Cache:     cache.New(time.Hour, 0),
+		CacheFile: filepath.Join(t.TempDir(), "cache"),
+	}
+	c.Cache.Set("blocking", blockingGob{}, 0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errc := make(chan error, 1)
+	go func() { errc <- c.WriteCacheContext(ctx) }()
+	<-encodeStarted
+	cancel()
+	if err := <-errc; err != context.Canceled {
+		t.Fatalf("got error %v, want %v", err, context.Canceled)
+	}
+
+	st := c.shared()
+	select {
+	case st.saving <- struct{}{}:
+		t.Fatal("next save could start while the abandoned encode was running")
+	default:
+	}
+	close(encodeRelease)
+	select {
+	case st.saving <- struct{}{}:
+		<-st.saving
+	case <-time.After(5 * time.Second):
+		t.Fatal("finished encode didn't release the save")
+	}
+}
+
+func TestWriteCacheFileMode(t *testing.T) {
+	c := &Client{
+		Cache:     cache.New(time.Hour, 0),
+		CacheFile: filepath.Join(t.TempDir(), "cache"),
+	}
+	if err := c.WriteCache(); err != nil {
+		t.Fatal(err)
+	}
+	fi, err := os.Stat(c.CacheFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fi.Mode().Perm() != 0644 {
+		t.Errorf("new cache file has mode %v, want 0644", fi.Mode().Perm())
+	}
+
+	if err := os.Chmod(c.CacheFile, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.WriteCache(); err != nil {
+		t.Fatal(err)
+	}
+	fi, err = os.Stat(c.CacheFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fi.Mode().Perm() != 0600 {
+		t.Errorf("rewritten cache file has mode %v, want its previous 0600", fi.Mode().Perm())
+	}
+}
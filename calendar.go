@@ -0,0 +1,63 @@
+package tvmaze
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// CalendarEntry is an episode on a calendar built by BuildCalendar.
+type CalendarEntry struct {
+	ShowID   int64
+	ShowName string
+	Episode  Episode
+	// AirTime is when the episode airs, in the show's AirLocation.
+	AirTime time.Time
+}
+
+// BuildCalendar queries tvmaze for the episodes of each show in showIDs, and
+// returns those airing within [from, to], as GetEpisodesInRange selects them,
+// sorted by air time.  Shows are fetched Concurrency at a time, one request each,
+// and fetching stops when ctx is done.  Entries for the shows that could be
+// fetched are returned even when others fail, along with BulkErrors holding the
+// failures.
+func (c *Client) BuildCalendar(ctx context.Context, showIDs []int64, from, to time.Time) ([]CalendarEntry, error) {
+	var (
+		mu      sync.Mutex
+		entries []CalendarEntry
+	)
+	errs := c.bulk(ctx, showIDs, func(ctx context.Context, id int64) error {
+		s, err := c.getShowWithEmbeds(ctx, id, []string{"episodes"})
+		if err != nil {
+			return err
+		}
+		loc, _ := s.AirLocation()
+
+		var found []CalendarEntry
+		for _, ep := range episodesInRange(s.Embedded.Episodes, from, to) {
+			airs, _ := ep.AirStampTime()
+			found = append(found, CalendarEntry{
+				ShowID:   s.ID,
+				ShowName: s.Name,
+				Episode:  ep,
+				AirTime:  airs.In(loc),
+			})
+		}
+		mu.Lock()
+		entries = append(entries, found...)
+		mu.Unlock()
+		return nil
+	})
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if !entries[i].AirTime.Equal(entries[j].AirTime) {
+			return entries[i].AirTime.Before(entries[j].AirTime)
+		}
+		return entries[i].ShowName < entries[j].ShowName
+	})
+	if len(errs) > 0 {
+		return entries, BulkErrors(errs)
+	}
+	return entries, nil
+}
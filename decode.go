@@ -0,0 +1,62 @@
+package tvmaze
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// DecodeError is returned when a tvmaze response doesn't decode into the Go type
+// expected for it, which usually means the API schema has drifted.
+type DecodeError struct {
+	// Endpoint is the route that was requested, e.g. "/shows/1/episodes".
+	Endpoint string
+	// Type is the Go type being decoded, e.g. "[]tvmaze.Episode".
+	Type string
+	// Field is the path to the offending field, when encoding/json reports one.
+	Field string
+	// Offset is the byte offset of the error in the response, when known.
+	Offset int64
+	Err    error
+}
+
+func (e *DecodeError) Error() string {
+	msg := fmt.Sprintf("decoding %s response into %s", e.Endpoint, e.Type)
+	if e.Field != "" {
+		msg += fmt.Sprintf(", field %s", e.Field)
+	}
+	if e.Offset > 0 {
+		msg += fmt.Sprintf(", offset %d", e.Offset)
+	}
+	return msg + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying encoding/json error.
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// decodeJSON unmarshals data from endpoint into v, wrapping any error in a
+// DecodeError.
+func decodeJSON(endpoint string, data []byte, v interface{}) error {
+	err := json.Unmarshal(data, v)
+	if err == nil {
+		return nil
+	}
+
+	derr := &DecodeError{
+		Endpoint: endpoint,
+		Type:     strings.TrimPrefix(fmt.Sprintf("%T", v), "*"),
+		Err:      err,
+	}
+	var typeErr *json.UnmarshalTypeError
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &typeErr) {
+		derr.Field = typeErr.Field
+		derr.Offset = typeErr.Offset
+	} else if errors.As(err, &syntaxErr) {
+		derr.Offset = syntaxErr.Offset
+	}
+	return derr
+}
@@ -0,0 +1,35 @@
+package tvmaze
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestDecodeErrorEnriched(t *testing.T) {
+	body := `[{"id": 1, "name": {"en": "Pilot"}}]`
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	})
+
+	_, err := c.GetEpisodes(1)
+	var derr *DecodeError
+	if !errors.As(err, &derr) {
+		t.Fatalf("got error %v, want a *DecodeError", err)
+	}
+	for _, want := range []string{"/shows/1/episodes", "[]tvmaze.Episode", "field name", "offset"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q doesn't mention %q", err, want)
+		}
+	}
+
+	body = `[{"id": 1,`
+	_, err = c.GetEpisodes(2)
+	if !errors.As(err, &derr) {
+		t.Fatalf("got error %v for malformed JSON, want a *DecodeError", err)
+	}
+	if derr.Offset == 0 {
+		t.Errorf("DecodeError for malformed JSON has no offset: %v", err)
+	}
+}
@@ -0,0 +1,269 @@
+package tvmaze
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+)
+
+// ShowWithEmbeds is a Show along with the resources requested through embed[].
+type ShowWithEmbeds struct {
+	Show
+	Embedded ShowEmbeds `json:"_embedded"`
+}
+
+// ShowEmbeds holds the resources tvmaze can embed in a show.  Fields for
+// resources that weren't requested, or that don't exist, are left nil, while a
+// requested list that's empty, like the crew of a show with none, is empty.
+type ShowEmbeds struct {
+	PreviousEpisode *Episode
+	NextEpisode     *Episode
+	Episodes        []Episode
+	Images          []ShowImage
+	Crew            []CrewMember
+}
+
+// UnmarshalJSON decodes the show, and its _embedded resources.
+func (s *ShowWithEmbeds) UnmarshalJSON(data []byte) error {
+	err := json.Unmarshal(data, &s.Show)
+	if err != nil {
+		return err
+	}
+
+	var aux struct {
+		Embedded ShowEmbeds `json:"_embedded"`
+	}
+	err = json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+	s.Embedded = aux.Embedded
+	return nil
+}
+
+// MarshalJSON encodes the show, with its embedded resources under _embedded, so
+// the result decodes back into an identical ShowWithEmbeds.
+func (s ShowWithEmbeds) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(s.Show)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	err = json.Unmarshal(data, &fields)
+	if err != nil {
+		return nil, err
+	}
+	fields["_embedded"], err = json.Marshal(s.Embedded)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(fields)
+}
+
+// GetShowWithEmbeds queries tvmaze for the show with id, embedding the named
+// resources, e.g. "episodes" or "nextepisode".
+func (c *Client) GetShowWithEmbeds(id int64, embeds ...string) (ShowWithEmbeds, error) {
+	return c.getShowWithEmbeds(context.Background(), id, embeds)
+}
+
+func (c *Client) getShowWithEmbeds(ctx context.Context, id int64, embeds []string) (ShowWithEmbeds, error) {
+	route := fmt.Sprintf("/shows/%d", id)
+	uri, err := url.Parse(c.BaseURI + route)
+	if err != nil {
+		return ShowWithEmbeds{}, err
+	}
+	uri.RawQuery = embedQuery(embeds).Encode()
+
+	jsondata, err := c.GoContext(ctx, uri)
+	if err != nil {
+		return ShowWithEmbeds{}, err
+	}
+
+	var show ShowWithEmbeds
+	err = decodeJSON(route, jsondata, &show)
+	if err != nil {
+		return ShowWithEmbeds{}, err
+	}
+	for _, embed := range embeds {
+		switch {
+		case embed == "episodes" && show.Embedded.Episodes == nil:
+			show.Embedded.Episodes = []Episode{}
+		case embed == "images" && show.Embedded.Images == nil:
+			show.Embedded.Images = []ShowImage{}
+		case embed == "crew" && show.Embedded.Crew == nil:
+			show.Embedded.Crew = []CrewMember{}
+		}
+	}
+	c.remember(show.Show)
+
+	return show, nil
+}
+
+// GetShowWithAdjacentEpisodes queries tvmaze for the show with id, along with
+// its previous and next episodes in a single request.  prev is nil for a show
+// that hasn't aired yet, and next is nil for a show with nothing scheduled.
+func (c *Client) GetShowWithAdjacentEpisodes(id int64) (show Show, prev, next *Episode, err error) {
+	s, err := c.GetShowWithEmbeds(id, "previousepisode", "nextepisode")
+	if err != nil {
+		return Show{}, nil, nil, err
+	}
+	return s.Show, s.Embedded.PreviousEpisode, s.Embedded.NextEpisode, nil
+}
+
+// GetEpisodesWithShow queries tvmaze for the show with id with its episodes
+// embedded, returning both from a single request, where GetShowByID and
+// GetEpisodes would take two.
+func (c *Client) GetEpisodesWithShow(showID int64) (Show, []Episode, error) {
+	s, err := c.GetShowWithEmbeds(showID, "episodes")
+	if err != nil {
+		return Show{}, nil, err
+	}
+	return s.Show, s.Embedded.Episodes, nil
+}
+
+// GetNextEpisode queries tvmaze for the next episode of the show with id.  It
+// returns nil if the show has nothing scheduled.
+func (c *Client) GetNextEpisode(showID int64) (*Episode, error) {
+	return c.getNextEpisode(context.Background(), showID)
+}
+
+func (c *Client) getNextEpisode(ctx context.Context, showID int64) (*Episode, error) {
+	s, err := c.getShowWithEmbeds(ctx, showID, []string{"nextepisode"})
+	if err != nil {
+		return nil, err
+	}
+	return s.Embedded.NextEpisode, nil
+}
+
+// EpisodeWithEmbeds is an Episode along with the resources requested through embed[].
+type EpisodeWithEmbeds struct {
+	Episode
+	Embedded EpisodeEmbeds `json:"_embedded"`
+}
+
+// EpisodeEmbeds holds the resources tvmaze can embed in an episode.  Show is nil
+// unless it was requested.
+type EpisodeEmbeds struct {
+	Show *Show
+}
+
+// UnmarshalJSON decodes the episode, and its _embedded resources.
+func (e *EpisodeWithEmbeds) UnmarshalJSON(data []byte) error {
+	err := json.Unmarshal(data, &e.Episode)
+	if err != nil {
+		return err
+	}
+
+	var aux struct {
+		Embedded EpisodeEmbeds `json:"_embedded"`
+	}
+	err = json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+	e.Embedded = aux.Embedded
+	return nil
+}
+
+// MarshalJSON encodes the episode, with its embedded resources under _embedded.
+func (e EpisodeWithEmbeds) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(e.Episode)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	err = json.Unmarshal(data, &fields)
+	if err != nil {
+		return nil, err
+	}
+	fields["_embedded"], err = json.Marshal(e.Embedded)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(fields)
+}
+
+// GetEpisodeByID queries tvmaze for the episode with id, embedding the named
+// resources, e.g. "show".
+func (c *Client) GetEpisodeByID(id int64, embeds ...string) (EpisodeWithEmbeds, error) {
+	return c.getEpisodeByID(context.Background(), id, embeds)
+}
+
+func (c *Client) getEpisodeByID(ctx context.Context, id int64, embeds []string) (EpisodeWithEmbeds, error) {
+	route := fmt.Sprintf("/episodes/%d", id)
+	uri, err := url.Parse(c.BaseURI + route)
+	if err != nil {
+		return EpisodeWithEmbeds{}, err
+	}
+	uri.RawQuery = embedQuery(embeds).Encode()
+
+	jsondata, err := c.GoContext(ctx, uri)
+	if err != nil {
+		return EpisodeWithEmbeds{}, err
+	}
+
+	var episode EpisodeWithEmbeds
+	err = decodeJSON(route, jsondata, &episode)
+	if err != nil {
+		return EpisodeWithEmbeds{}, err
+	}
+	if episode.Embedded.Show != nil {
+		c.remember(*episode.Embedded.Show)
+	}
+
+	return episode, nil
+}
+
+// GetLinkedEpisode queries tvmaze for the episode l links to, such as an
+// episode's Links.PreviousEpisode.  The episode's ID is taken from the link, and
+// fetched from BaseURI like any other request.
+func (c *Client) GetLinkedEpisode(l Link) (Episode, error) {
+	id, err := linkID(l, "episodes")
+	if err != nil {
+		return Episode{}, err
+	}
+	episode, err := c.GetEpisodeByID(id)
+	if err != nil {
+		return Episode{}, err
+	}
+	return episode.Episode, nil
+}
+
+// WalkPreviousEpisodes follows the previous episode links back from e, up to max
+// hops, and returns the episodes found, most recent first.  It stops early,
+// without error, at an episode with no previous episode link.
+func (c *Client) WalkPreviousEpisodes(e Episode, max int) ([]Episode, error) {
+	var chain []Episode
+	seen := map[int64]bool{e.ID: true}
+	for len(chain) < max && e.Links.PreviousEpisode.Href != "" {
+		prev, err := c.GetLinkedEpisode(e.Links.PreviousEpisode)
+		if err != nil {
+			return chain, err
+		}
+		if seen[prev.ID] {
+			break
+		}
+		seen[prev.ID] = true
+		chain = append(chain, prev)
+		e = prev
+	}
+	return chain, nil
+}
+
+// embedQuery returns the embed[] query parameters for embeds.  They're sorted, so
+// the same embeds requested in any order share a cache entry.
+func embedQuery(embeds []string) url.Values {
+	sorted := make([]string, len(embeds))
+	copy(sorted, embeds)
+	sort.Strings(sorted)
+
+	query := url.Values{}
+	for _, embed := range sorted {
+		query.Add("embed[]", embed)
+	}
+	return query
+}
@@ -0,0 +1,93 @@
+package tvmaze
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestShowWithEmbedsRoundTrip(t *testing.T) {
+	data := []byte(`{
+		"id": 1,
+		"name": "Under the Dome",
+		"runtime": null,
+		"_embedded": {
+			"nextepisode": {"id": 3, "season": 2, "number": 1, "runtime": 60},
+			"episodes": [
+				{"id": 1, "season": 1, "number": 1, "runtime": 60},
+				{"id": 2, "season": 1, "number": null, "runtime": null}
+			],
+			"crew": []
+		}
+	}`)
+	var show ShowWithEmbeds
+	if err := json.Unmarshal(data, &show); err != nil {
+		t.Fatal(err)
+	}
+	if show.Embedded.NextEpisode == nil || len(show.Embedded.Episodes) != 2 {
+		t.Fatalf("embeds weren't decoded: %+v", show.Embedded)
+	}
+
+	encoded, err := json.Marshal(show)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := fields["_embedded"]; !ok {
+		t.Errorf("encoded show has no _embedded key: %s", encoded)
+	}
+
+	var decoded ShowWithEmbeds
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, show) {
+		t.Errorf("round trip changed the show:\ngot  %+v\nwant %+v", decoded, show)
+	}
+}
+
+func TestEmbedOrderSharesCacheEntry(t *testing.T) {
+	hits := 0
+	var embeds []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		embeds = r.URL.Query()["embed[]"]
+		w.Write([]byte(`{"id": 1, "_embedded": {"episodes": [], "crew": []}}`))
+	})
+
+	if _, err := c.GetShowWithEmbeds(1, "episodes", "crew"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetShowWithEmbeds(1, "crew", "episodes"); err != nil {
+		t.Fatal(err)
+	}
+	if hits != 1 {
+		t.Errorf("got %d requests, want both orderings to share a cache entry", hits)
+	}
+	if want := []string{"crew", "episodes"}; !reflect.DeepEqual(embeds, want) {
+		t.Errorf("requested embeds %q, want %q", embeds, want)
+	}
+}
+
+func TestShowWithEmbedsRequestedListsEmpty(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 1, "name": "No Crew", "_embedded": {}}`))
+	})
+	show, err := c.GetShowWithEmbeds(1, "crew", "episodes", "images")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if show.Embedded.Crew == nil || len(show.Embedded.Crew) != 0 {
+		t.Errorf("Crew = %#v, want an empty list", show.Embedded.Crew)
+	}
+	if show.Embedded.Episodes == nil || show.Embedded.Images == nil {
+		t.Errorf("requested lists left nil: %+v", show.Embedded)
+	}
+	if show.Embedded.NextEpisode != nil {
+		t.Error("unrequested NextEpisode was set")
+	}
+}
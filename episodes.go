@@ -0,0 +1,251 @@
+package tvmaze
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// EpisodeKey identifies an episode within its show by season and number, and is
+// comparable, so it can be used as a map key.  Specials in season 0 are keyed
+// like any other season.
+type EpisodeKey struct {
+	Season int
+	Number int
+}
+
+// Key returns the EpisodeKey for the episode.
+func (e Episode) Key() EpisodeKey {
+	return EpisodeKey{Season: e.Season, Number: e.Number}
+}
+
+// Code returns the episode code, like S01E02.  Season and number are padded to
+// two digits, and grow as needed, so episode 100 is S01E100 and a special in
+// season 0 is S00E01.
+func (e Episode) Code() string {
+	return fmt.Sprintf("S%02dE%02d", e.Season, e.Number)
+}
+
+var episodeCode = regexp.MustCompile(`^[Ss](\d{1,3})[Ee](\d{1,3})$`)
+
+// ParseEpisodeCode parses an episode code like S01E02, the form returned by
+// Episode.Code.  It's case insensitive, and season and number may each be one to
+// three digits, so s1e2, S01E02 and S01E100 are all accepted.
+func ParseEpisodeCode(code string) (EpisodeKey, error) {
+	m := episodeCode.FindStringSubmatch(code)
+	if m == nil {
+		return EpisodeKey{}, fmt.Errorf("invalid episode code %q", code)
+	}
+	season, _ := strconv.Atoi(m[1])
+	number, _ := strconv.Atoi(m[2])
+	return EpisodeKey{Season: season, Number: number}, nil
+}
+
+// SpecialsOrder is where SortEpisodes places specials, the episodes of season 0.
+type SpecialsOrder int
+
+const (
+	// SpecialsFirst sorts specials as season 0, ahead of season 1.
+	SpecialsFirst SpecialsOrder = iota
+	// SpecialsAtEnd sorts specials after the last regular season.
+	SpecialsAtEnd
+	// SpecialsByAirDate places each special just before the first regular
+	// episode that airs after it.  Specials without an air date go at the end.
+	SpecialsByAirDate
+)
+
+// SortEpisodes sorts eps by season, then by number.  Episodes without a number
+// sort after the numbered episodes of their season, in air date order.  Specials
+// are placed by order, SpecialsFirst when it's omitted.
+func SortEpisodes(eps []Episode, order ...SpecialsOrder) {
+	sort.SliceStable(eps, func(i, j int) bool {
+		return episodeLess(eps[i], eps[j])
+	})
+	if len(order) == 0 || order[0] == SpecialsFirst {
+		return
+	}
+
+	n := 0
+	for n < len(eps) && eps[n].Season == 0 {
+		n++
+	}
+	specials := append([]Episode(nil), eps[:n]...)
+	regular := append([]Episode(nil), eps[n:]...)
+	switch order[0] {
+	case SpecialsAtEnd:
+		copy(eps, regular)
+		copy(eps[len(regular):], specials)
+	case SpecialsByAirDate:
+		sort.SliceStable(specials, func(i, j int) bool {
+			a, aErr := specials[i].AirStampTime()
+			b, bErr := specials[j].AirStampTime()
+			if aErr != nil || bErr != nil {
+				return aErr == nil && bErr != nil
+			}
+			return a.Before(b)
+		})
+		copy(eps, mergeByAirDate(regular, specials))
+	}
+}
+
+// mergeByAirDate merges specials, sorted by air date, into regular, keeping the
+// order of regular, and placing each special before the first regular episode
+// airing after it.
+func mergeByAirDate(regular, specials []Episode) []Episode {
+	merged := make([]Episode, 0, len(regular)+len(specials))
+	for len(regular) > 0 && len(specials) > 0 {
+		sAirs, sErr := specials[0].AirStampTime()
+		rAirs, rErr := regular[0].AirStampTime()
+		if sErr == nil && rErr == nil && sAirs.Before(rAirs) {
+			merged = append(merged, specials[0])
+			specials = specials[1:]
+		} else if sErr != nil {
+			merged = append(merged, regular...)
+			regular = nil
+		} else {
+			merged = append(merged, regular[0])
+			regular = regular[1:]
+		}
+	}
+	merged = append(merged, regular...)
+	return append(merged, specials...)
+}
+
+// episodeLess reports whether a sorts before b, as SortEpisodes orders them.
+func episodeLess(a, b Episode) bool {
+	if a.Season != b.Season {
+		return a.Season < b.Season
+	}
+	an, bn := a.NumberOrNil(), b.NumberOrNil()
+	switch {
+	case an != nil && bn != nil:
+		return *an < *bn
+	case an != nil || bn != nil:
+		return an != nil
+	}
+	aAirs, aErr := a.AirStampTime()
+	bAirs, bErr := b.AirStampTime()
+	if aErr != nil || bErr != nil {
+		return aErr == nil && bErr != nil
+	}
+	return aAirs.Before(bAirs)
+}
+
+// GetEpisodesSorted is like GetEpisodes, but the episodes are sorted with
+// SortEpisodes rather than left in the order tvmaze returned them.
+func (c *Client) GetEpisodesSorted(showID int64) ([]Episode, error) {
+	episodes, err := c.GetEpisodes(showID)
+	if err != nil {
+		return nil, err
+	}
+	SortEpisodes(episodes)
+	return episodes, nil
+}
+
+// SeasonBlock is one season of a show's episodes, sorted as by SortEpisodes.
+// Premiere and Finale point into Episodes, and skip episodes without a number.
+// Specials in season 0 have neither.
+type SeasonBlock struct {
+	Season   int
+	Episodes []Episode
+	Premiere *Episode
+	// Finale is nil for the last season in the list, since the episodes alone
+	// can't tell whether it's finished or still airing, with its last episode
+	// not announced yet.
+	Finale *Episode
+}
+
+// SeasonBlocks splits eps into seasons, in season order, flagging each season's
+// premiere and finale.  eps isn't modified.
+func SeasonBlocks(eps []Episode) []SeasonBlock {
+	sorted := make([]Episode, len(eps))
+	copy(sorted, eps)
+	SortEpisodes(sorted)
+
+	var blocks []SeasonBlock
+	for i := 0; i < len(sorted); {
+		j := i
+		for j < len(sorted) && sorted[j].Season == sorted[i].Season {
+			j++
+		}
+		block := SeasonBlock{Season: sorted[i].Season, Episodes: sorted[i:j:j]}
+		for k := range block.Episodes {
+			if block.Season == 0 || block.Episodes[k].NumberOrNil() == nil {
+				continue
+			}
+			if block.Premiere == nil {
+				block.Premiere = &block.Episodes[k]
+			}
+			if j < len(sorted) {
+				block.Finale = &block.Episodes[k]
+			}
+		}
+		blocks = append(blocks, block)
+		i = j
+	}
+	return blocks
+}
+
+// ShowID returns the ID of the episode's show, from its show link.  It returns an
+// error if the episode has no show link, which tvmaze only includes on some
+// responses.
+func (e Episode) ShowID() (int64, error) {
+	return linkID(e.Links.Show, "shows")
+}
+
+// linkID parses the ID from l, a link like https://api.tvmaze.com/shows/1 to a
+// resource of kind, e.g. "shows".
+func linkID(l Link, kind string) (int64, error) {
+	if l.Href == "" {
+		return 0, fmt.Errorf("no %s link", kind)
+	}
+	uri, err := url.Parse(l.Href)
+	if err != nil {
+		return 0, err
+	}
+	dir, base := path.Split(uri.Path)
+	if path.Base(dir) != kind {
+		return 0, fmt.Errorf("%s isn't a link to %s", l.Href, kind)
+	}
+	return strconv.ParseInt(base, 10, 64)
+}
+
+// DiffEpisodes compares two fetches of a show's episodes by ID.  added are in
+// after but not before, removed are in before but not after, and changed are
+// the after version of episodes whose name, air stamp, runtime or summary
+// differ.  added and changed are in the order of after, removed in the order of
+// before.
+func DiffEpisodes(before, after []Episode) (added, changed, removed []Episode) {
+	old := make(map[int64]Episode, len(before))
+	for _, ep := range before {
+		old[ep.ID] = ep
+	}
+	current := make(map[int64]bool, len(after))
+	for _, ep := range after {
+		current[ep.ID] = true
+		prev, ok := old[ep.ID]
+		switch {
+		case !ok:
+			added = append(added, ep)
+		case episodeChanged(prev, ep):
+			changed = append(changed, ep)
+		}
+	}
+	for _, ep := range before {
+		if !current[ep.ID] {
+			removed = append(removed, ep)
+		}
+	}
+	return added, changed, removed
+}
+
+// episodeChanged reports whether the fields DiffEpisodes compares differ.
+func episodeChanged(a, b Episode) bool {
+	return a.Name != b.Name ||
+		a.AirStamp != b.AirStamp ||
+		a.Runtime != b.Runtime || a.nullRuntime != b.nullRuntime ||
+		a.Summary != b.Summary
+}
@@ -0,0 +1,196 @@
+package tvmaze
+
+import (
+	"encoding/json"
+	"math/rand"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestSeasonBlocks(t *testing.T) {
+	eps := []Episode{
+		{ID: 1, Season: 1, Number: 1, AirStamp: "2020-01-01T01:00:00+00:00"},
+		{ID: 2, Season: 1, Number: 2, AirStamp: "2020-01-08T01:00:00+00:00"},
+		{ID: 3, Season: 0, Number: 1, AirStamp: "2020-01-05T01:00:00+00:00"},
+		{ID: 4, Season: 2, Number: 1, AirStamp: "2021-01-01T01:00:00+00:00"},
+		{ID: 5, Season: 2, Number: 2, AirStamp: "2021-01-08T01:00:00+00:00"},
+	}
+	finaleID := func(b SeasonBlock) int64 {
+		if b.Finale == nil {
+			return 0
+		}
+		return b.Finale.ID
+	}
+
+	blocks := SeasonBlocks(eps)
+	if len(blocks) != 3 {
+		t.Fatalf("got %d blocks, want 3", len(blocks))
+	}
+	if specials := blocks[0]; specials.Premiere != nil || specials.Finale != nil {
+		t.Error("specials got a premiere or finale")
+	}
+	if blocks[1].Premiere == nil || blocks[1].Premiere.ID != 1 || finaleID(blocks[1]) != 2 {
+		t.Errorf("season 1 premiere %v, finale %d, want 1 and 2", blocks[1].Premiere, finaleID(blocks[1]))
+	}
+	if blocks[2].Premiere == nil || blocks[2].Premiere.ID != 4 {
+		t.Errorf("season 2 premiere %v, want 4", blocks[2].Premiere)
+	}
+	if blocks[2].Finale != nil {
+		t.Errorf("last season got finale %d, want none, as it may still be airing", blocks[2].Finale.ID)
+	}
+}
+
+func TestEpisodeCode(t *testing.T) {
+	tests := []struct {
+		ep   Episode
+		code string
+	}{
+		{Episode{Season: 1, Number: 2}, "S01E02"},
+		{Episode{Season: 1, Number: 100}, "S01E100"},
+		{Episode{Season: 0, Number: 1}, "S00E01"},
+		{Episode{Season: 12, Number: 1000}, "S12E1000"},
+	}
+	for _, tt := range tests {
+		if got := tt.ep.Code(); got != tt.code {
+			t.Errorf("Code() of S%d E%d = %q, want %q", tt.ep.Season, tt.ep.Number, got, tt.code)
+		}
+	}
+}
+
+func TestParseEpisodeCode(t *testing.T) {
+	tests := []struct {
+		code string
+		key  EpisodeKey
+	}{
+		{"S01E02", EpisodeKey{1, 2}},
+		{"s1e2", EpisodeKey{1, 2}},
+		{"S01E100", EpisodeKey{1, 100}},
+		{"S00E01", EpisodeKey{0, 1}},
+		{"S100E001", EpisodeKey{100, 1}},
+	}
+	for _, tt := range tests {
+		key, err := ParseEpisodeCode(tt.code)
+		if err != nil {
+			t.Errorf("ParseEpisodeCode(%q): %v", tt.code, err)
+			continue
+		}
+		if key != tt.key {
+			t.Errorf("ParseEpisodeCode(%q) = %v, want %v", tt.code, key, tt.key)
+		}
+	}
+
+	for _, code := range []string{"", "S01", "1x02", "S01E1000", "S01E02 "} {
+		if _, err := ParseEpisodeCode(code); err == nil {
+			t.Errorf("ParseEpisodeCode(%q) = nil error", code)
+		}
+	}
+}
+
+// sortFixture is a show's episodes in sorted order: a special, then two seasons,
+// the first ending with an unnumbered episode.
+const sortFixture = `[
+	{"id": 1, "season": 0, "number": 1, "airstamp": "2020-01-05T01:00:00+00:00"},
+	{"id": 2, "season": 1, "number": 1, "airstamp": "2020-01-01T01:00:00+00:00"},
+	{"id": 3, "season": 1, "number": 2, "airstamp": "2020-01-08T01:00:00+00:00"},
+	{"id": 4, "season": 1, "number": 10, "airstamp": "2020-03-01T01:00:00+00:00"},
+	{"id": 5, "season": 1, "number": null, "airstamp": "2020-03-08T01:00:00+00:00"},
+	{"id": 6, "season": 2, "number": 1, "airstamp": "2021-01-01T01:00:00+00:00"}
+]`
+
+func episodeIDs(eps []Episode) []int64 {
+	ids := make([]int64, len(eps))
+	for i, ep := range eps {
+		ids[i] = ep.ID
+	}
+	return ids
+}
+
+func TestSortEpisodesShuffled(t *testing.T) {
+	var eps []Episode
+	if err := json.Unmarshal([]byte(sortFixture), &eps); err != nil {
+		t.Fatal(err)
+	}
+	want := episodeIDs(eps)
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		r.Shuffle(len(eps), func(i, j int) { eps[i], eps[j] = eps[j], eps[i] })
+		SortEpisodes(eps)
+		if got := episodeIDs(eps); !reflect.DeepEqual(got, want) {
+			t.Fatalf("sorted to %v, want %v", got, want)
+		}
+	}
+}
+
+func TestGetEpisodesSorted(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[
+			{"id": 6, "season": 2, "number": 1},
+			{"id": 3, "season": 1, "number": 2},
+			{"id": 2, "season": 1, "number": 1}
+		]`))
+	})
+	eps, err := c.GetEpisodesSorted(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := episodeIDs(eps), []int64{2, 3, 6}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got episodes %v, want %v", got, want)
+	}
+}
+
+func TestDiffEpisodes(t *testing.T) {
+	before := []Episode{
+		{ID: 1, Name: "Pilot", AirStamp: "2020-01-01T01:00:00+00:00"},
+		{ID: 2, Name: "TBA", AirStamp: "2020-01-08T01:00:00+00:00"},
+		{ID: 3, Name: "Cancelled", AirStamp: "2020-01-15T01:00:00+00:00"},
+	}
+	after := []Episode{
+		{ID: 1, Name: "Pilot", AirStamp: "2020-01-01T01:00:00+00:00"},
+		{ID: 2, Name: "TBA", AirStamp: "2020-02-08T01:00:00+00:00"},
+		{ID: 4, Name: "New", AirStamp: "2020-02-15T01:00:00+00:00"},
+	}
+	added, changed, removed := DiffEpisodes(before, after)
+	if got := episodeIDs(added); !reflect.DeepEqual(got, []int64{4}) {
+		t.Errorf("added %v, want [4]", got)
+	}
+	if got := episodeIDs(removed); !reflect.DeepEqual(got, []int64{3}) {
+		t.Errorf("removed %v, want [3]", got)
+	}
+	if len(changed) != 1 || changed[0].ID != 2 || changed[0].AirStamp != after[1].AirStamp {
+		t.Errorf("changed %v, want the new version of episode 2", changed)
+	}
+
+	if added, changed, removed := DiffEpisodes(after, after); added != nil || changed != nil || removed != nil {
+		t.Errorf("diff of identical lists = %v, %v, %v, want nothing", added, changed, removed)
+	}
+}
+
+func TestSortEpisodesSpecialsOrder(t *testing.T) {
+	var fixture []Episode
+	if err := json.Unmarshal([]byte(sortFixture), &fixture); err != nil {
+		t.Fatal(err)
+	}
+	// A second special, without an air date.
+	fixture = append(fixture, Episode{ID: 7, Season: 0, Number: 2})
+
+	tests := []struct {
+		name  string
+		order []SpecialsOrder
+		want  []int64
+	}{
+		{"default", nil, []int64{1, 7, 2, 3, 4, 5, 6}},
+		{"SpecialsFirst", []SpecialsOrder{SpecialsFirst}, []int64{1, 7, 2, 3, 4, 5, 6}},
+		{"SpecialsAtEnd", []SpecialsOrder{SpecialsAtEnd}, []int64{2, 3, 4, 5, 6, 1, 7}},
+		{"SpecialsByAirDate", []SpecialsOrder{SpecialsByAirDate}, []int64{2, 1, 3, 4, 5, 6, 7}},
+	}
+	for _, tt := range tests {
+		eps := append([]Episode(nil), fixture...)
+		rand.New(rand.NewSource(1)).Shuffle(len(eps), func(i, j int) { eps[i], eps[j] = eps[j], eps[i] })
+		SortEpisodes(eps, tt.order...)
+		if got := episodeIDs(eps); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: sorted to %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
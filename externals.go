@@ -0,0 +1,53 @@
+package tvmaze
+
+import (
+	"context"
+	"sync"
+)
+
+// GroupByExternalIMDB groups shows by their IMDB ID, so tvmaze entries sharing an
+// IMDB ID can be spotted.  Shows without an IMDB ID are grouped under "".
+func GroupByExternalIMDB(shows []Show) map[string][]Show {
+	groups := make(map[string][]Show)
+	for _, show := range shows {
+		groups[show.Externals.IMDB] = append(groups[show.Externals.IMDB], show)
+	}
+	return groups
+}
+
+// GetExternals queries tvmaze for each show in ids, and returns the show's IDs on
+// other sites.  Shows are fetched Concurrency at a time, and the externals that
+// could be fetched are returned even when others fail, with a *BulkError for
+// each failure.
+func (c *Client) GetExternals(ids []int64) (map[int64]External, []error) {
+	var mu sync.Mutex
+	externals := make(map[int64]External, len(ids))
+	errs := c.bulk(context.Background(), ids, func(ctx context.Context, id int64) error {
+		show, err := c.getShowByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		externals[id] = show.Externals
+		mu.Unlock()
+		return nil
+	})
+	return externals, errs
+}
+
+// InvertExternals maps the IMDB and TheTVDB IDs in externals, as returned by
+// GetExternals, back to tvmaze show IDs.  Shows without an ID on a site are left
+// out of that site's map.
+func InvertExternals(externals map[int64]External) (imdb map[string]int64, thetvdb map[int64]int64) {
+	imdb = make(map[string]int64)
+	thetvdb = make(map[int64]int64)
+	for id, ext := range externals {
+		if ext.IMDB != "" {
+			imdb[ext.IMDB] = id
+		}
+		if ext.TheTVDB != 0 {
+			thetvdb[ext.TheTVDB] = id
+		}
+	}
+	return imdb, thetvdb
+}
@@ -0,0 +1,69 @@
+package tvmaze
+
+import "strings"
+
+// HasGenre reports whether the show has genre, compared without regard to case or
+// surrounding space.
+func (s Show) HasGenre(genre string) bool {
+	return genreSet(s.Genres)[normalizeGenre(genre)]
+}
+
+// SharedGenres returns the genres a and b have in common, compared without regard
+// to case or surrounding space, in a's order and spelling.
+func SharedGenres(a, b Show) []string {
+	inB := genreSet(b.Genres)
+	seen := make(map[string]bool)
+	var shared []string
+	for _, genre := range a.Genres {
+		g := normalizeGenre(genre)
+		if inB[g] && !seen[g] {
+			seen[g] = true
+			shared = append(shared, genre)
+		}
+	}
+	return shared
+}
+
+// GenreSimilarity returns the Jaccard index of the genres of a and b: the number
+// of shared genres divided by the number of distinct genres across both shows.
+// It's 0 when neither show has any genres.
+func GenreSimilarity(a, b Show) float64 {
+	union := genreSet(a.Genres)
+	for g := range genreSet(b.Genres) {
+		union[g] = true
+	}
+	if len(union) == 0 {
+		return 0
+	}
+	return float64(len(SharedGenres(a, b))) / float64(len(union))
+}
+
+// genreSet returns the normalized genres as a set.
+func genreSet(genres []string) map[string]bool {
+	set := make(map[string]bool, len(genres))
+	for _, genre := range genres {
+		set[normalizeGenre(genre)] = true
+	}
+	return set
+}
+
+func normalizeGenre(genre string) string {
+	return strings.ToLower(strings.TrimSpace(genre))
+}
+
+// AnimeHeuristic decides whether Show.IsAnime reports a show as anime, and may be
+// replaced to change the rules.  By default a show is anime if tvmaze gives it
+// the Anime genre, or if it's from Japan and has the Animation genre or type.
+var AnimeHeuristic = func(s Show) bool {
+	genres := genreSet(s.Genres)
+	if genres["anime"] {
+		return true
+	}
+	animated := genres["animation"] || normalizeGenre(s.Type) == "animation"
+	return animated && s.PrimaryCountry().Code == "JP"
+}
+
+// IsAnime reports whether the show is anime, as decided by AnimeHeuristic.
+func (s Show) IsAnime() bool {
+	return AnimeHeuristic(s)
+}
@@ -0,0 +1,29 @@
+package tvmaze
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSharedGenres(t *testing.T) {
+	tests := []struct {
+		name       string
+		a, b       []string
+		shared     []string
+		similarity float64
+	}{
+		{"overlapping", []string{"Drama", "Comedy", "Crime"}, []string{" comedy", "Crime", "Horror"}, []string{"Comedy", "Crime"}, 0.5},
+		{"disjoint", []string{"Drama"}, []string{"Comedy", "Horror"}, nil, 0},
+		{"identical", []string{"Drama", "drama"}, []string{"DRAMA"}, []string{"Drama"}, 1},
+		{"none", nil, nil, nil, 0},
+	}
+	for _, tt := range tests {
+		a, b := Show{Genres: tt.a}, Show{Genres: tt.b}
+		if got := SharedGenres(a, b); !reflect.DeepEqual(got, tt.shared) {
+			t.Errorf("%s: SharedGenres() = %q, want %q", tt.name, got, tt.shared)
+		}
+		if got := GenreSimilarity(a, b); got != tt.similarity {
+			t.Errorf("%s: GenreSimilarity() = %v, want %v", tt.name, got, tt.similarity)
+		}
+	}
+}
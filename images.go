@@ -0,0 +1,130 @@
+package tvmaze
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// ShowImage represents an image from a show's image gallery.  Type is one of
+// "poster", "background", "banner" or "typography", and Main marks the image
+// tvmaze features for that type.
+type ShowImage struct {
+	ID          int64
+	Type        string
+	Main        bool
+	Resolutions Resolutions
+}
+
+// Resolutions represents the sizes an image is available in.  Medium is only
+// present for some images.
+type Resolutions struct {
+	Original Resolution
+	Medium   Resolution
+}
+
+// Resolution represents one size of an image.
+type Resolution struct {
+	URL    string
+	Width  int
+	Height int
+}
+
+// GetShowImages queries tvmaze, and returns the image gallery for the show with id.
+func (c *Client) GetShowImages(id int64) ([]ShowImage, error) {
+	route := fmt.Sprintf("/shows/%d/images", id)
+	uri, err := url.Parse(c.BaseURI + route)
+	if err != nil {
+		return nil, err
+	}
+
+	jsondata, err := c.Go(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	var images []ShowImage
+	err = decodeJSON(route, jsondata, &images)
+	if err != nil {
+		return nil, err
+	}
+
+	return images, nil
+}
+
+// PosterURL returns the URL of the show's main poster from its embedded images
+// (a 2:3 image), or Image.Original when no poster was embedded.
+func (s ShowWithEmbeds) PosterURL() string {
+	return s.galleryURL("poster")
+}
+
+// BackgroundURL returns the URL of the show's main background from its embedded
+// images (a 16:9 image), or Image.Original when no background was embedded.
+func (s ShowWithEmbeds) BackgroundURL() string {
+	return s.galleryURL("background")
+}
+
+// galleryURL returns the original resolution URL of the main embedded image of
+// kind, or of the first one when none is main, falling back to Image.Original.
+func (s ShowWithEmbeds) galleryURL(kind string) string {
+	var found *ShowImage
+	for i, img := range s.Embedded.Images {
+		if img.Type != kind || img.Resolutions.Original.URL == "" {
+			continue
+		}
+		if found == nil || (img.Main && !found.Main) {
+			found = &s.Embedded.Images[i]
+		}
+	}
+	if found == nil {
+		return s.Image.Original
+	}
+	return found.Resolutions.Original.URL
+}
+
+// ImageURL returns the URL of the show's image in the first of prefs that's
+// available, where prefs are sizes, "original" or "medium".  Without prefs,
+// "original" is preferred to "medium".  It returns "" if the show has no image.
+func (s Show) ImageURL(prefs ...string) string {
+	if len(prefs) == 0 {
+		prefs = []string{"original", "medium"}
+	}
+	for _, pref := range prefs {
+		switch pref {
+		case "original":
+			if s.Image.Original != "" {
+				return s.Image.Original
+			}
+		case "medium":
+			if s.Image.Medium != "" {
+				return s.Image.Medium
+			}
+		}
+	}
+	return ""
+}
+
+// ImageURL is like Show.ImageURL, but tries the main poster from the embedded
+// images first, which is available in higher resolutions than the show image.
+func (s ShowWithEmbeds) ImageURL(prefs ...string) string {
+	if len(prefs) == 0 {
+		prefs = []string{"original", "medium"}
+	}
+	for _, img := range s.Embedded.Images {
+		if img.Type != "poster" || !img.Main {
+			continue
+		}
+		for _, pref := range prefs {
+			switch pref {
+			case "original":
+				if img.Resolutions.Original.URL != "" {
+					return img.Resolutions.Original.URL
+				}
+			case "medium":
+				if img.Resolutions.Medium.URL != "" {
+					return img.Resolutions.Medium.URL
+				}
+			}
+		}
+	}
+	return s.Show.ImageURL(prefs...)
+}
@@ -0,0 +1,143 @@
+package tvmaze
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/url"
+	"strconv"
+)
+
+// IndexOption configures WalkShowIndex.
+type IndexOption func(*indexWalk)
+
+type indexWalk struct {
+	filter   func(Show) bool
+	maxPages int
+}
+
+// WithShowFilter drops shows for which keep returns false from each index page,
+// before the page is handed to the walk function.
+func WithShowFilter(keep func(Show) bool) IndexOption {
+	return func(w *indexWalk) {
+		w.filter = keep
+	}
+}
+
+// WithMaxPages stops the walk after n pages.  The whole index is walked when n is
+// 0 or less.
+func WithMaxPages(n int) IndexOption {
+	return func(w *indexWalk) {
+		w.maxPages = n
+	}
+}
+
+// GetShowIndex returns a page of the tvmaze show index.  Pages are numbered from 0,
+// hold up to 250 shows ordered by ID, and ErrNotFound is returned past the last page.
+func (c *Client) GetShowIndex(page int) ([]Show, error) {
+	return c.getShowIndex(context.Background(), page)
+}
+
+func (c *Client) getShowIndex(ctx context.Context, page int) ([]Show, error) {
+	route := "/shows"
+	uri, err := url.Parse(c.BaseURI + route)
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	query.Add("page", strconv.Itoa(page))
+	uri.RawQuery = query.Encode()
+
+	jsondata, err := c.GoContext(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+
+	var shows []Show
+	err = decodeJSON(route, jsondata, &shows)
+	if err != nil {
+		return nil, err
+	}
+	c.remember(shows...)
+
+	return shows, nil
+}
+
+// WalkShowIndex calls fn with every page of the show index in order, starting at
+// page 0.  It stops without error once the index is exhausted, and otherwise stops
+// at the first error from tvmaze, from fn, or from ctx.  Pages emptied by a
+// WithShowFilter are still passed to fn.
+func (c *Client) WalkShowIndex(ctx context.Context, fn func(page int, shows []Show) error, opts ...IndexOption) error {
+	var w indexWalk
+	for _, opt := range opts {
+		opt(&w)
+	}
+
+	for page := 0; w.maxPages <= 0 || page < w.maxPages; page++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		shows, err := c.getShowIndex(ctx, page)
+		if err == ErrNotFound {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if w.filter != nil {
+			kept := shows[:0]
+			for _, show := range shows {
+				if w.filter(show) {
+					kept = append(kept, show)
+				}
+			}
+			shows = kept
+		}
+		err = fn(page, shows)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ExportShowIndex walks the show index, writing each show to w as a line of JSON
+// (NDJSON).  Only one page is held in memory at a time, and w is flushed after
+// each page if it has a Flush method, like a *bufio.Writer.
+func (c *Client) ExportShowIndex(ctx context.Context, w io.Writer, opts ...IndexOption) error {
+	enc := json.NewEncoder(w)
+	return c.WalkShowIndex(ctx, func(page int, shows []Show) error {
+		for _, show := range shows {
+			err := enc.Encode(show)
+			if err != nil {
+				return err
+			}
+		}
+		switch f := w.(type) {
+		case interface{ Flush() error }:
+			return f.Flush()
+		case interface{ Flush() }:
+			f.Flush()
+		}
+		return nil
+	}, opts...)
+}
+
+// FindShowsByGenreInIndex walks up to maxPages pages of the show index, and
+// returns the shows with genre.  tvmaze can't search by genre, so this is one
+// request per page of 250 shows, and the whole index, when maxPages is 0 or
+// less, is several hundred requests.
+func (c *Client) FindShowsByGenreInIndex(ctx context.Context, genre string, maxPages int) ([]Show, error) {
+	var found []Show
+	err := c.WalkShowIndex(ctx, func(page int, shows []Show) error {
+		found = append(found, shows...)
+		return nil
+	}, WithMaxPages(maxPages), WithShowFilter(func(s Show) bool {
+		return s.HasGenre(genre)
+	}))
+	if err != nil {
+		return nil, err
+	}
+	return found, nil
+}
@@ -0,0 +1,38 @@
+package tvmaze
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestWalkShowIndexFilter(t *testing.T) {
+	pages := map[string]string{
+		"0": `[{"id": 1, "status": "Running"}, {"id": 2, "status": "Ended"}]`,
+		"1": `[{"id": 3, "status": "Ended"}, {"id": 4, "status": "Running"}]`,
+	}
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		page, ok := pages[r.URL.Query().Get("page")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(page))
+	})
+
+	var ids []int64
+	running := func(s Show) bool { return s.Status == "Running" }
+	err := c.WalkShowIndex(context.Background(), func(page int, shows []Show) error {
+		for _, show := range shows {
+			ids = append(ids, show.ID)
+		}
+		return nil
+	}, WithShowFilter(running))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int64{1, 4}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("walked shows %v, want %v", ids, want)
+	}
+}
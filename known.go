@@ -0,0 +1,53 @@
+package tvmaze
+
+import "sort"
+
+// remember adds shows to the client's index of shows it has decoded.
+func (c *Client) remember(shows ...Show) {
+	st := c.shared()
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if st.known == nil {
+		st.known = make(map[int64]Show)
+	}
+	for _, show := range shows {
+		st.known[show.ID] = show
+	}
+}
+
+// KnownShow returns the show with id if the client has already decoded it from
+// a tvmaze response, without making a request.
+func (c *Client) KnownShow(id int64) (Show, bool) {
+	st := c.shared()
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	show, ok := st.known[id]
+	return show, ok
+}
+
+// KnownNetworks returns the distinct networks of the shows the client has
+// already decoded, sorted by name.  Streaming shows have no network, and are
+// skipped.
+func (c *Client) KnownNetworks() []Network {
+	st := c.shared()
+	st.mu.Lock()
+	seen := make(map[int]Network)
+	for _, show := range st.known {
+		if show.Network.ID != 0 {
+			seen[show.Network.ID] = show.Network
+		}
+	}
+	st.mu.Unlock()
+
+	networks := make([]Network, 0, len(seen))
+	for _, network := range seen {
+		networks = append(networks, network)
+	}
+	sort.Slice(networks, func(i, j int) bool {
+		if networks[i].Name != networks[j].Name {
+			return networks[i].Name < networks[j].Name
+		}
+		return networks[i].ID < networks[j].ID
+	})
+	return networks
+}
@@ -0,0 +1,62 @@
+package tvmaze
+
+import "strings"
+
+// languageCodes maps the language names tvmaze uses, lower cased, to ISO 639-1
+// codes.
+var languageCodes = map[string]string{
+	"arabic":     "ar",
+	"bengali":    "bn",
+	"bulgarian":  "bg",
+	"catalan":    "ca",
+	"chinese":    "zh",
+	"croatian":   "hr",
+	"czech":      "cs",
+	"danish":     "da",
+	"dutch":      "nl",
+	"english":    "en",
+	"estonian":   "et",
+	"finnish":    "fi",
+	"french":     "fr",
+	"german":     "de",
+	"greek":      "el",
+	"hebrew":     "he",
+	"hindi":      "hi",
+	"hungarian":  "hu",
+	"icelandic":  "is",
+	"indonesian": "id",
+	"irish":      "ga",
+	"italian":    "it",
+	"japanese":   "ja",
+	"korean":     "ko",
+	"latvian":    "lv",
+	"lithuanian": "lt",
+	"malay":      "ms",
+	"norwegian":  "no",
+	"persian":    "fa",
+	"polish":     "pl",
+	"portuguese": "pt",
+	"romanian":   "ro",
+	"russian":    "ru",
+	"serbian":    "sr",
+	"slovak":     "sk",
+	"slovenian":  "sl",
+	"spanish":    "es",
+	"swedish":    "sv",
+	"tagalog":    "tl",
+	"tamil":      "ta",
+	"telugu":     "te",
+	"thai":       "th",
+	"turkish":    "tr",
+	"ukrainian":  "uk",
+	"urdu":       "ur",
+	"vietnamese": "vi",
+	"welsh":      "cy",
+}
+
+// LanguageCode returns the ISO 639-1 code for the show's Language, e.g. "en" for
+// English or "ja" for Japanese.  It returns "" for a language that isn't known,
+// or a show without one.
+func (s Show) LanguageCode() string {
+	return languageCodes[strings.ToLower(strings.TrimSpace(s.Language))]
+}
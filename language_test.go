@@ -0,0 +1,28 @@
+package tvmaze
+
+import "testing"
+
+func TestLanguageCode(t *testing.T) {
+	tests := []struct {
+		language, code string
+	}{
+		{"English", "en"},
+		{"Japanese", "ja"},
+		{"Spanish", "es"},
+		{"French", "fr"},
+		{"German", "de"},
+		{"Korean", "ko"},
+		{"Chinese", "zh"},
+		{"Portuguese", "pt"},
+		{" english ", "en"},
+		{"KOREAN", "ko"},
+		{"Klingon", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		show := Show{Language: tt.language}
+		if got := show.LanguageCode(); got != tt.code {
+			t.Errorf("LanguageCode() for %q = %q, want %q", tt.language, got, tt.code)
+		}
+	}
+}
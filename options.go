@@ -0,0 +1,37 @@
+package tvmaze
+
+import "time"
+
+// Option configures a Client created by NewClient.
+type Option func(*Client)
+
+// WithAcceptLanguage sets the Accept-Language header sent with every request.
+func WithAcceptLanguage(lang string) Option {
+	return func(c *Client) {
+		c.AcceptLanguage = lang
+	}
+}
+
+// WithUpdatesTTL sets how long GetShowUpdates responses are cached.
+func WithUpdatesTTL(ttl time.Duration) Option {
+	return func(c *Client) {
+		c.UpdatesTTL = ttl
+	}
+}
+
+// WithFullShow sets FullShow, so FindShow returns the full show.
+func WithFullShow() Option {
+	return func(c *Client) {
+		c.FullShow = true
+	}
+}
+
+// WithoutJanitor creates the client without the goroutine NewClient otherwise runs
+// to sweep out expired responses, which short-lived tools and leak-checked tests
+// have no use for.  Expired responses are still never served, but stay in memory,
+// and in the cache file, until overwritten.
+func WithoutJanitor() Option {
+	return func(c *Client) {
+		c.noJanitor = true
+	}
+}
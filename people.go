@@ -0,0 +1,86 @@
+package tvmaze
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// Person represents a cast or crew member.
+type Person struct {
+	ID       int64
+	URL      string
+	Name     string
+	Country  Country
+	Birthday string
+	Deathday string
+	Gender   string
+	Image    Image
+	Updated  int64
+	Links    Links `json:"_links"`
+}
+
+// CrewMember represents a person's crew credit on a show.  Type is the role,
+// e.g. "Creator" or "Executive Producer".
+type CrewMember struct {
+	Type   string
+	Person Person
+}
+
+// CastCredit represents a person's cast credit on a show.  Self is set when the
+// person plays themselves, and Voice for a voice role.
+type CastCredit struct {
+	Self  bool
+	Voice bool
+	Links CastCreditLinks `json:"_links"`
+}
+
+// CastCreditLinks represents CastCredit links.
+type CastCreditLinks struct {
+	Show      Link
+	Character Link
+}
+
+// PersonWithEmbeds is a Person along with the resources requested through embed[].
+type PersonWithEmbeds struct {
+	Person
+	Embedded PersonEmbeds `json:"_embedded"`
+}
+
+// PersonEmbeds holds the resources tvmaze can embed in a person.  Lists that were
+// requested are empty rather than nil for a person with none.
+type PersonEmbeds struct {
+	CastCredits []CastCredit
+	Images      []ShowImage
+}
+
+// GetPersonByID queries tvmaze for the person with id, embedding the named
+// resources, "castcredits" or "images".
+func (c *Client) GetPersonByID(id int64, embeds ...string) (PersonWithEmbeds, error) {
+	route := fmt.Sprintf("/people/%d", id)
+	uri, err := url.Parse(c.BaseURI + route)
+	if err != nil {
+		return PersonWithEmbeds{}, err
+	}
+	uri.RawQuery = embedQuery(embeds).Encode()
+
+	jsondata, err := c.Go(uri)
+	if err != nil {
+		return PersonWithEmbeds{}, err
+	}
+
+	var person PersonWithEmbeds
+	err = decodeJSON(route, jsondata, &person)
+	if err != nil {
+		return PersonWithEmbeds{}, err
+	}
+	for _, embed := range embeds {
+		switch {
+		case embed == "castcredits" && person.Embedded.CastCredits == nil:
+			person.Embedded.CastCredits = []CastCredit{}
+		case embed == "images" && person.Embedded.Images == nil:
+			person.Embedded.Images = []ShowImage{}
+		}
+	}
+
+	return person, nil
+}
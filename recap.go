@@ -0,0 +1,50 @@
+package tvmaze
+
+import "strings"
+
+// RecapKeywords are the words IsLikelyRecap looks for, without regard to case, in
+// an episode's name and summary.
+var RecapKeywords = []string{"recap", "clip show"}
+
+// RecapRuntimeRatio is the fraction of the average episode runtime below which
+// LikelyRecaps treats an episode as a recap.
+var RecapRuntimeRatio = 0.5
+
+// IsLikelyRecap guesses whether the episode is a recap or clip show, because its
+// name or summary contains one of RecapKeywords.  It's a heuristic, and will
+// both miss recaps and flag episodes that merely mention one.
+func (e Episode) IsLikelyRecap() bool {
+	text := strings.ToLower(e.Name + " " + e.Summary)
+	for _, keyword := range RecapKeywords {
+		if strings.Contains(text, strings.ToLower(keyword)) {
+			return true
+		}
+	}
+	return false
+}
+
+// LikelyRecaps returns the episodes in eps that IsLikelyRecap flags, along with
+// those whose runtime is under RecapRuntimeRatio of the average runtime of eps.
+// Like IsLikelyRecap, it's a heuristic.  Episodes without a runtime are only
+// judged by IsLikelyRecap.
+func LikelyRecaps(eps []Episode) []Episode {
+	var total, n int
+	for _, ep := range eps {
+		if ep.Runtime > 0 {
+			total += ep.Runtime
+			n++
+		}
+	}
+	short := 0.0
+	if n > 0 {
+		short = float64(total) / float64(n) * RecapRuntimeRatio
+	}
+
+	var recaps []Episode
+	for _, ep := range eps {
+		if ep.IsLikelyRecap() || (ep.Runtime > 0 && float64(ep.Runtime) < short) {
+			recaps = append(recaps, ep)
+		}
+	}
+	return recaps
+}
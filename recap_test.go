@@ -0,0 +1,55 @@
+package tvmaze
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIsLikelyRecap(t *testing.T) {
+	tests := []struct {
+		name    string
+		summary string
+		recap   bool
+	}{
+		{"Season 1 Recap", "", true},
+		{"The Story So Far", "<p>A RECAP of the season.</p>", true},
+		{"Remember When", "<p>A clip show looking back.</p>", true},
+		{"Pilot", "<p>A paper company's staff.</p>", false},
+		{"Clips", "<p>The clip of the show.</p>", false},
+	}
+	for _, tt := range tests {
+		ep := Episode{Name: tt.name, Summary: tt.summary}
+		if got := ep.IsLikelyRecap(); got != tt.recap {
+			t.Errorf("IsLikelyRecap() of %q, %q = %v, want %v", tt.name, tt.summary, got, tt.recap)
+		}
+	}
+}
+
+func TestRecapKeywordsConfigurable(t *testing.T) {
+	defer func(keywords []string) { RecapKeywords = keywords }(RecapKeywords)
+	RecapKeywords = []string{"Rückblick"}
+
+	if !(Episode{Name: "Der große RÜCKBLICK"}).IsLikelyRecap() {
+		t.Error("custom keyword wasn't matched")
+	}
+	if (Episode{Name: "Season 1 Recap"}).IsLikelyRecap() {
+		t.Error("replaced default keyword still matched")
+	}
+}
+
+func TestLikelyRecapsRuntime(t *testing.T) {
+	eps := []Episode{
+		{ID: 1, Name: "Pilot", Runtime: 60},
+		{ID: 2, Name: "Bonus", Runtime: 20},
+		{ID: 3, Name: "Recap", Runtime: 60},
+		{ID: 4, Name: "Finale", Runtime: 60},
+		{ID: 5, Name: "Unknown"},
+	}
+	var ids []int64
+	for _, ep := range LikelyRecaps(eps) {
+		ids = append(ids, ep.ID)
+	}
+	if want := []int64{2, 3}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("got recaps %v, want %v", ids, want)
+	}
+}
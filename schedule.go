@@ -0,0 +1,143 @@
+package tvmaze
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// ScheduleEpisode is an episode from a schedule, along with its show.
+type ScheduleEpisode struct {
+	Episode
+	Show Show
+	// NextEpisode is the show's next episode, set by EnrichScheduleWithNext.  It
+	// isn't part of the tvmaze response, and isn't encoded by MarshalJSON.
+	NextEpisode *Episode
+}
+
+// UnmarshalJSON decodes a schedule episode.  The broadcast schedule has the show
+// at the top level, and the web schedule embeds it under _embedded.
+func (e *ScheduleEpisode) UnmarshalJSON(data []byte) error {
+	err := json.Unmarshal(data, &e.Episode)
+	if err != nil {
+		return err
+	}
+
+	var aux struct {
+		Show     *Show
+		Embedded struct {
+			Show *Show
+		} `json:"_embedded"`
+	}
+	err = json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+	e.Show = Show{}
+	if aux.Show != nil {
+		e.Show = *aux.Show
+	} else if aux.Embedded.Show != nil {
+		e.Show = *aux.Embedded.Show
+	}
+	return nil
+}
+
+// MarshalJSON encodes the schedule episode with its show at the top level.
+func (e ScheduleEpisode) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(e.Episode)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	err = json.Unmarshal(data, &fields)
+	if err != nil {
+		return nil, err
+	}
+	fields["show"], err = json.Marshal(e.Show)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(fields)
+}
+
+// GetSchedule queries tvmaze for the episodes airing on broadcast tv in country,
+// an ISO 3166-1 code like "US", on date.  A zero date means today, and an empty
+// country means the US, as chosen by tvmaze.
+func (c *Client) GetSchedule(date time.Time, country string) ([]ScheduleEpisode, error) {
+	return c.getSchedule("/schedule", date, country)
+}
+
+// GetWebSchedule queries tvmaze for the episodes released by streaming services
+// in country, an ISO 3166-1 code like "US", on date.  A zero date means today,
+// and an empty country means worldwide.
+func (c *Client) GetWebSchedule(date time.Time, country string) ([]ScheduleEpisode, error) {
+	return c.getSchedule("/schedule/web", date, country)
+}
+
+func (c *Client) getSchedule(route string, date time.Time, country string) ([]ScheduleEpisode, error) {
+	uri, err := url.Parse(c.BaseURI + route)
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	if !date.IsZero() {
+		query.Add("date", date.Format("2006-01-02"))
+	}
+	if country != "" {
+		query.Add("country", country)
+	}
+	uri.RawQuery = query.Encode()
+
+	jsondata, err := c.Go(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	var episodes []ScheduleEpisode
+	err = decodeJSON(route, jsondata, &episodes)
+	if err != nil {
+		return nil, err
+	}
+
+	return episodes, nil
+}
+
+// EnrichScheduleWithNext sets NextEpisode on each of eps, by querying tvmaze for
+// the next episode of every distinct show in eps.  That is one request per show,
+// which for a full day's schedule is hundreds of requests, so it's best run with
+// the cache enabled.  Requests are made Concurrency at a time, and stop when ctx
+// is done.  Episodes of shows that couldn't be fetched are left unchanged, and
+// the failures are returned as BulkErrors.
+func (c *Client) EnrichScheduleWithNext(ctx context.Context, eps []ScheduleEpisode) error {
+	ids := make([]int64, 0, len(eps))
+	for _, ep := range eps {
+		ids = append(ids, ep.Show.ID)
+	}
+
+	var mu sync.Mutex
+	next := make(map[int64]*Episode, len(ids))
+	errs := c.bulk(ctx, ids, func(ctx context.Context, id int64) error {
+		episode, err := c.getNextEpisode(ctx, id)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		next[id] = episode
+		mu.Unlock()
+		return nil
+	})
+
+	for i := range eps {
+		if episode, ok := next[eps[i].Show.ID]; ok {
+			eps[i].NextEpisode = episode
+		}
+	}
+	if len(errs) > 0 {
+		return BulkErrors(errs)
+	}
+	return nil
+}
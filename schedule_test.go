@@ -0,0 +1,44 @@
+package tvmaze
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestGetWebScheduleCountry(t *testing.T) {
+	var query url.Values
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/schedule/web" {
+			t.Errorf("requested %s, want /schedule/web", r.URL.Path)
+		}
+		query = r.URL.Query()
+		w.Write([]byte(`[{"id": 1, "name": "Pilot", "_embedded": {"show": {"id": 2, "name": "Show"}}}]`))
+	})
+
+	episodes, err := c.GetWebSchedule(time.Time{}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := query["country"]; ok {
+		t.Errorf("query %q has country, want it omitted for a worldwide schedule", query.Encode())
+	}
+	if _, ok := query["date"]; ok {
+		t.Errorf("query %q has date, want it omitted for a zero date", query.Encode())
+	}
+	if len(episodes) != 1 || episodes[0].Show.ID != 2 {
+		t.Errorf("got episodes %+v, want episode 1 of show 2", episodes)
+	}
+
+	_, err = c.GetWebSchedule(time.Date(2021, 3, 4, 0, 0, 0, 0, time.UTC), "GB")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := query.Get("country"); got != "GB" {
+		t.Errorf("country = %q, want GB", got)
+	}
+	if got := query.Get("date"); got != "2021-03-04" {
+		t.Errorf("date = %q, want 2021-03-04", got)
+	}
+}
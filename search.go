@@ -0,0 +1,78 @@
+package tvmaze
+
+import "sort"
+
+// SearchShowsLimit searches tvmaze for q, and returns at most limit of the
+// candidates, best match first.  A limit of 0 or less returns them all.
+func (c *Client) SearchShowsLimit(q string, limit int) ([]Candidate, error) {
+	candidates, err := c.GetShow(q)
+	if err != nil {
+		return nil, err
+	}
+	if limit > 0 && limit < len(candidates) {
+		candidates = candidates[:limit]
+	}
+	return candidates, nil
+}
+
+// SearchShowsMinScore searches tvmaze for q, and returns the candidates scoring
+// at least min, in the order tvmaze returned them.
+func (c *Client) SearchShowsMinScore(q string, min float64) ([]Candidate, error) {
+	candidates, err := c.GetShow(q)
+	if err != nil {
+		return nil, err
+	}
+	kept := candidates[:0]
+	for _, cand := range candidates {
+		if cand.Score >= min {
+			kept = append(kept, cand)
+		}
+	}
+	return kept, nil
+}
+
+// ShowSummary is the part of a search candidate a disambiguation list shows, like
+// "Doctor Who (1963), BBC One".
+type ShowSummary struct {
+	ID   int64
+	Name string
+	Year int
+	// Airing is the name of the show's web channel or network, from AiringName.
+	Airing string
+	Score  float64
+}
+
+// SearchShowsAnnotated searches tvmaze for q, and returns a ShowSummary of each
+// candidate, best match first.  Year is 0 for a show that hasn't premiered.
+func (c *Client) SearchShowsAnnotated(q string) ([]ShowSummary, error) {
+	candidates, err := c.GetShow(q)
+	if err != nil {
+		return nil, err
+	}
+	summaries := make([]ShowSummary, 0, len(candidates))
+	for _, cand := range candidates {
+		summaries = append(summaries, ShowSummary{
+			ID:     cand.Show.ID,
+			Name:   cand.Show.Name,
+			Year:   cand.Show.PremieredYear(),
+			Airing: cand.Show.AiringName(),
+			Score:  cand.Score,
+		})
+	}
+	return summaries, nil
+}
+
+// SearchScores searches tvmaze for q, and returns just the candidates' scores,
+// highest first, to help pick a min for SearchShowsMinScore.
+func (c *Client) SearchScores(q string) ([]float64, error) {
+	candidates, err := c.GetShow(q)
+	if err != nil {
+		return nil, err
+	}
+	scores := make([]float64, 0, len(candidates))
+	for _, cand := range candidates {
+		scores = append(scores, cand.Score)
+	}
+	sort.Sort(sort.Reverse(sort.Float64Slice(scores)))
+	return scores, nil
+}
@@ -0,0 +1,30 @@
+package tvmaze
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestSearchShowsMinScore(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[
+			{"score": 0.9, "show": {"id": 1}},
+			{"score": 0.2, "show": {"id": 2}},
+			{"score": 0.5, "show": {"id": 3}},
+			{"score": 0.7, "show": {"id": 4}}
+		]`))
+	})
+
+	candidates, err := c.SearchShowsMinScore("office", 0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []int64
+	for _, cand := range candidates {
+		ids = append(ids, cand.Show.ID)
+	}
+	if want := []int64{1, 3, 4}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("got candidates %v, want %v in tvmaze's order", ids, want)
+	}
+}
@@ -0,0 +1,66 @@
+package tvmaze
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+// PlainSummary returns the show's summary, which tvmaze provides as HTML, as
+// plain text.  It's "" for a show without a summary, which tvmaze sends as null.
+func (s Show) PlainSummary() string {
+	return plainText(s.Summary)
+}
+
+// SummaryTeaser returns PlainSummary cut to at most n characters, ending at a
+// word boundary with "…" when it's cut, the "…" counting towards n.  It's "" for
+// a show without a summary.
+func (s Show) SummaryTeaser(n int) string {
+	return teaser(s.PlainSummary(), n)
+}
+
+// PlainSummary returns the episode's summary, which tvmaze provides as HTML, as
+// plain text.  It's "" for an episode without a summary, which tvmaze sends as
+// null.
+func (e Episode) PlainSummary() string {
+	return plainText(e.Summary)
+}
+
+// SummaryTeaser returns PlainSummary cut to at most n characters, ending at a
+// word boundary with "…" when it's cut, the "…" counting towards n.  It's "" for
+// an episode without a summary.
+func (e Episode) SummaryTeaser(n int) string {
+	return teaser(e.PlainSummary(), n)
+}
+
+var htmlTag = regexp.MustCompile(`<[^>]*>`)
+
+// plainText strips the tags from s, unescapes its entities, and collapses runs
+// of space.
+func plainText(s string) string {
+	if strings.TrimSpace(s) == "" {
+		return ""
+	}
+	text := html.UnescapeString(htmlTag.ReplaceAllString(s, " "))
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// teaserTrim is the space and punctuation teaser trims from the end of a cut.
+const teaserTrim = " ,.;:"
+
+// teaser cuts s to at most n characters at a word boundary, adding "…" within
+// those n when it cuts.  s is returned whole if n is 0 or less.
+func teaser(s string, n int) string {
+	runes := []rune(s)
+	if n <= 0 || len(runes) <= n {
+		return s
+	}
+	cut := string(runes[:n-1])
+	if !strings.ContainsRune(teaserTrim, runes[n-1]) {
+		// The cut is mid-word, so drop the partial word.
+		if i := strings.LastIndex(cut, " "); i > 0 {
+			cut = cut[:i]
+		}
+	}
+	return strings.TrimRight(cut, teaserTrim) + "…"
+}
@@ -0,0 +1,60 @@
+package tvmaze
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestNullSummary(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/show_null_summary.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var show Show
+	if err := json.Unmarshal(data, &show); err != nil {
+		t.Fatal(err)
+	}
+	if got := show.PlainSummary(); got != "" {
+		t.Errorf("PlainSummary() = %q, want \"\"", got)
+	}
+	if got := show.SummaryTeaser(10); got != "" {
+		t.Errorf("SummaryTeaser(10) = %q, want \"\"", got)
+	}
+
+	var ep Episode
+	if err := json.Unmarshal([]byte(`{"id": 1, "summary": null}`), &ep); err != nil {
+		t.Fatal(err)
+	}
+	if got := ep.PlainSummary(); got != "" {
+		t.Errorf("episode PlainSummary() = %q, want \"\"", got)
+	}
+	ep.Summary = "<p> </p>"
+	if got := ep.SummaryTeaser(10); got != "" {
+		t.Errorf("SummaryTeaser(10) of an empty paragraph = %q, want \"\"", got)
+	}
+}
+
+func TestSummaryTeaser(t *testing.T) {
+	show := Show{Summary: "<p>A <b>paper</b> company's staff, filmed as a documentary.</p>"}
+	tests := []struct {
+		n    int
+		want string
+	}{
+		{0, "A paper company's staff, filmed as a documentary."},
+		{100, "A paper company's staff, filmed as a documentary."},
+		{24, "A paper company's staff…"},
+		{23, "A paper company's…"},
+		{1, "…"},
+	}
+	for _, tt := range tests {
+		got := show.SummaryTeaser(tt.n)
+		if got != tt.want {
+			t.Errorf("SummaryTeaser(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+		if tt.n > 0 && utf8.RuneCountInString(got) > tt.n {
+			t.Errorf("SummaryTeaser(%d) = %q, longer than %d characters", tt.n, got, tt.n)
+		}
+	}
+}
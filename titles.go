@@ -0,0 +1,108 @@
+package tvmaze
+
+import (
+	"strings"
+	"unicode"
+)
+
+// NormalizeTitle returns s in the form FindShow compares show titles in: lower
+// case, with punctuation removed, runs of space collapsed, and a leading "the"
+// dropped.  "The Office (US)" normalizes to "office us".
+func NormalizeTitle(s string) string {
+	clean := strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			return unicode.ToLower(r)
+		case unicode.IsSpace(r):
+			return ' '
+		case r == '\'':
+			// "Grey's" should match "Greys", not "Grey s".
+			return -1
+		}
+		return ' '
+	}, s)
+	words := strings.Fields(clean)
+	if len(words) > 1 && words[0] == "the" {
+		words = words[1:]
+	}
+	return strings.Join(words, " ")
+}
+
+// TitlesEqual reports whether a and b are the same title once normalized by
+// NormalizeTitle.
+func TitlesEqual(a, b string) bool {
+	return NormalizeTitle(a) == NormalizeTitle(b)
+}
+
+// titleHasPrefix reports whether title begins with prefix once both are
+// normalized by NormalizeTitle.
+func titleHasPrefix(title, prefix string) bool {
+	return strings.HasPrefix(NormalizeTitle(title), NormalizeTitle(prefix))
+}
+
+// ConfidenceTitleWeight is the share of MatchConfidence that comes from
+// TitleSimilarity, with the remainder coming from the candidate's score.
+var ConfidenceTitleWeight = 0.7
+
+// TitleSimilarity returns how alike titles a and b are, from 0 to 1, once both
+// are normalized by NormalizeTitle: 1 minus their edit distance divided by the
+// length of the longer title.
+func TitleSimilarity(a, b string) float64 {
+	ra, rb := []rune(NormalizeTitle(a)), []rune(NormalizeTitle(b))
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(editDistance(ra, rb))/float64(longest)
+}
+
+// MatchConfidence returns a confidence, from 0 to 1, that matched, a candidate
+// from GetShow, is the show query meant:
+//
+//	ConfidenceTitleWeight*TitleSimilarity + (1-ConfidenceTitleWeight)*Score
+//
+// with Score clamped to [0, 1].  It takes the Candidate rather than the Show
+// FindShow returns, since a Show doesn't carry its search score.
+func MatchConfidence(query string, matched Candidate) float64 {
+	score := matched.Score
+	if score < 0 {
+		score = 0
+	} else if score > 1 {
+		score = 1
+	}
+	w := ConfidenceTitleWeight
+	return w*TitleSimilarity(query, matched.Show.Name) + (1-w)*score
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = minInt(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+func minInt(first int, rest ...int) int {
+	for _, n := range rest {
+		if n < first {
+			first = n
+		}
+	}
+	return first
+}
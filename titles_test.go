@@ -0,0 +1,39 @@
+package tvmaze
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestFindShowNormalizesPrefix(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[
+			{"score": 9, "show": {"id": 1, "name": "The Office", "network": {"id": 1, "country": {"code": "GB"}}}},
+			{"score": 8, "show": {"id": 2, "name": "The Office (US)", "network": {"id": 2, "country": {"code": "US"}}}}
+		]`))
+	})
+	c.Region = "US"
+
+	show, err := c.FindShow("office")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if show.ID != 2 {
+		t.Errorf("got show %d, want 2, the US show matched by normalized prefix", show.ID)
+	}
+}
+
+func TestMatchConfidence(t *testing.T) {
+	exact := MatchConfidence("the office", Candidate{Score: 0.9, Show: Show{Name: "The Office"}})
+	if want := ConfidenceTitleWeight + (1-ConfidenceTitleWeight)*0.9; exact != want {
+		t.Errorf("exact title confidence = %v, want %v", exact, want)
+	}
+	lowScore := MatchConfidence("the office", Candidate{Score: 0.1, Show: Show{Name: "The Office"}})
+	if lowScore >= exact {
+		t.Errorf("low score confidence %v isn't below high score confidence %v", lowScore, exact)
+	}
+	clamped := MatchConfidence("office", Candidate{Score: 20, Show: Show{Name: "Office"}})
+	if clamped != 1 {
+		t.Errorf("confidence with score over 1 = %v, want 1", clamped)
+	}
+}
@@ -2,22 +2,40 @@
 package tvmaze
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
-	"log"
 	"net/http"
 	"net/url"
 	"os"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	cache "github.com/robfig/go-cache"
 )
 
+// ErrNotFound is returned when tvmaze responds with 404 Not Found.
+var ErrNotFound = errors.New("Request failed: Not Found")
+
+// Logger is the logging interface used by Client.  *log.Logger satisfies it, and
+// adapters for other logging packages need only implement Printf.
+type Logger interface {
+	Printf(format string, args ...interface{})
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Printf(format string, args ...interface{}) {}
+
 // Client is a tvmaze client.
 type Client struct {
+	// Debug enables logging of cache hits and misses to Logger.
 	Debug     bool
 	BaseURI   string
 	Region    string
@@ -27,42 +45,289 @@ type Client struct {
 	*http.Client
 	// UserAgent may be set to identify your application.
 	UserAgent string
+	// AcceptLanguage is sent as the Accept-Language header when set.  Responses
+	// are cached separately per language.
+	AcceptLanguage string
+	// FullShow makes FindShow follow up a match with GetShowByID, returning the
+	// full show rather than the lighter show from the search results, at the cost
+	// of an extra request.
+	FullShow bool
+	// QueryNormalizer rewrites search queries before they're sent, so equivalent
+	// queries share a cache entry.  NormalizeQuery is used when it's nil.
+	QueryNormalizer func(string) string
+	// Logger receives the client's log output.  Nothing is logged when it's nil.
+	Logger Logger
+	// MaxRetries is how many times DefaultRetryPolicy retries a request failing
+	// with 429 Too Many Requests or a 5xx status.  Retries are disabled when it's 0.
+	MaxRetries int
+	// RetryWait is DefaultRetryPolicy's backoff before the first retry, doubled
+	// for each retry after that.  It defaults to one second.
+	RetryWait time.Duration
+	// RetryPolicy is consulted after every failed attempt at a request, with
+	// either the non-200 response or the error making the request, and the
+	// number of attempts made so far.  It returns whether to try again, and how
+	// long to wait first.  DefaultRetryPolicy is used when it's nil.
+	RetryPolicy func(resp *http.Response, err error, attempt int) (retry bool, wait time.Duration)
+	// UpdatesTTL is how long GetShowUpdates responses are cached, which is usually
+	// much shorter than for other responses.  It defaults to five minutes.
+	UpdatesTTL time.Duration
+	// Concurrency is how many requests the bulk methods, like GetEpisodesByIDs,
+	// make at once.  It defaults to 4.
+	Concurrency int
+	// PersistToFile reports whether the response to uri should be written to
+	// CacheFile by WriteCache.  Everything is written when it's nil.
+	PersistToFile func(uri *url.URL) bool
+	// MaxConcurrentRequests caps the requests to tvmaze the client, and its
+	// clones, have in flight at once, across all methods.  Cache hits don't
+	// count.  There's no cap when it's 0, and changes after the first request
+	// have no effect.
+	MaxConcurrentRequests int
+	// Now returns the current time for methods that depend on it, like Tonight,
+	// and is mainly for tests to fix the date.  time.Now is used when it's nil.
+	// Functions that aren't methods take the current time as an argument instead.
+	Now func() time.Time
+
+	noJanitor bool
+	stateOnce sync.Once
+	state     *cacheState
 }
 
-// NewClient returns a ready to use Client.
-func NewClient(cachefile string) (*Client, error) {
-	c := cache.New(time.Minute*60*24*7, time.Minute*60)
-	if _, err := os.Stat(cachefile); err == nil {
-		err := c.LoadFile(cachefile)
-		if err != nil {
-			return nil, err
+// cacheState is the bookkeeping that goes with a client's Cache.  It's shared by
+// the client and its clones, like the Cache itself.
+type cacheState struct {
+	mu    sync.Mutex
+	known map[int64]Show
+	// volatile holds the keys of the responses rejected by PersistToFile.
+	volatile map[string]bool
+	sem      chan struct{}
+	// saving holds a token while WriteCache is saving to CacheFile.
+	saving chan struct{}
+	// stopSweep is closed by Close to stop the sweep goroutine.
+	stopSweep chan struct{}
+	closeOnce sync.Once
+	// dirty is 1 when responses for CacheFile have been cached since the last
+	// WriteCache.
+	dirty int32
+}
+
+// shared returns the client's cacheState, creating it on first use.
+func (c *Client) shared() *cacheState {
+	c.stateOnce.Do(func() {
+		if c.state == nil {
+			c.state = &cacheState{saving: make(chan struct{}, 1)}
 		}
-	}
+	})
+	return c.state
+}
 
+// NewClient returns a ready to use Client, configured by opts.
+func NewClient(cachefile string, opts ...Option) (*Client, error) {
 	timeout := time.Duration(180 * time.Second)
 	client := &http.Client{
 		Timeout: timeout,
 	}
 
-	return &Client{
-		Cache:     c,
+	tvmaze := &Client{
 		CacheFile: cachefile,
 		BaseURI:   "http://api.tvmaze.com",
 		Client:    client,
 		UserAgent: "github.com/rickyninja/tvmaze",
-	}, nil
+	}
+	for _, opt := range opts {
+		opt(tvmaze)
+	}
+
+	c := cache.New(cacheExpiration, 0)
+	if _, err := os.Stat(cachefile); err == nil {
+		err := c.LoadFile(cachefile)
+		if err != nil {
+			return nil, err
+		}
+	}
+	tvmaze.Cache = c
+
+	if !tvmaze.noJanitor {
+		stop := make(chan struct{})
+		tvmaze.shared().stopSweep = stop
+		go sweep(c, sweepInterval, stop)
+	}
+
+	return tvmaze, nil
+}
+
+// cacheExpiration is how long responses are cached by default.
+const cacheExpiration = time.Minute * 60 * 24 * 7
+
+// sweepInterval is how often expired responses are swept from the cache.
+const sweepInterval = time.Minute * 60
+
+// sweep deletes expired responses from c every interval, until stop is closed.
+// It stands in for go-cache's janitor, which can't be stopped directly.
+func sweep(c *cache.Cache, interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.DeleteExpired()
+		case <-stop:
+			return
+		}
+	}
 }
 
-// WriteCache writes cache contents to disk.
+// Close stops the goroutine NewClient starts to sweep expired responses from
+// Cache.  The goroutine is shared with the client's clones, so closing any of them
+// stops it for all.  The client remains usable after Close, and expired responses
+// are still never served, but they stay in memory until overwritten.
+func (c *Client) Close() error {
+	st := c.shared()
+	st.closeOnce.Do(func() {
+		if st.stopSweep != nil {
+			close(st.stopSweep)
+		}
+	})
+	return nil
+}
+
+// Clone returns a copy of the client's configuration, which can be changed without
+// affecting c, e.g. to turn off UseCache for one request.  The clone shares c's
+// Cache, along with the index of decoded shows, the MaxConcurrentRequests limit,
+// and what WriteCache needs to know about the cached responses, so responses
+// cached by either are seen, and written, by both.  The embedded *http.Client is
+// copied, so its Timeout can be changed, but the Transport is shared.
+func (c *Client) Clone() *Client {
+	var httpClient *http.Client
+	if c.Client != nil {
+		hc := *c.Client
+		httpClient = &hc
+	}
+	return &Client{
+		Debug:           c.Debug,
+		BaseURI:         c.BaseURI,
+		Region:          c.Region,
+		Cache:           c.Cache,
+		CacheFile:       c.CacheFile,
+		UseCache:        c.UseCache,
+		Client:          httpClient,
+		UserAgent:       c.UserAgent,
+		AcceptLanguage:  c.AcceptLanguage,
+		FullShow:        c.FullShow,
+		QueryNormalizer: c.QueryNormalizer,
+		Logger:          c.Logger,
+		MaxRetries:      c.MaxRetries,
+		RetryWait:       c.RetryWait,
+		RetryPolicy:     c.RetryPolicy,
+		UpdatesTTL:      c.UpdatesTTL,
+		Concurrency:     c.Concurrency,
+		PersistToFile:   c.PersistToFile,
+		Now:             c.Now,
+
+		MaxConcurrentRequests: c.MaxConcurrentRequests,
+		noJanitor:             c.noJanitor,
+		state:                 c.shared(),
+	}
+}
+
+// WriteCache writes cache contents to disk.  Responses rejected by PersistToFile
+// are left out of the file, but stay in the in-memory cache.
 func (c *Client) WriteCache() error {
-	err := c.Cache.SaveFile(c.CacheFile)
+	return c.WriteCacheContext(context.Background())
+}
+
+// WriteCacheContext is like WriteCache, but gives up when ctx is done, even in the
+// middle of encoding the cache.  The cache is written to a temporary file that
+// replaces CacheFile only once it's complete, so an interrupted save leaves the
+// previous file intact.
+func (c *Client) WriteCacheContext(ctx context.Context) error {
+	st := c.shared()
+	dirty := atomic.SwapInt32(&st.dirty, 0)
+	err := c.saveFile(ctx)
 	if err != nil {
+		atomic.CompareAndSwapInt32(&st.dirty, 0, dirty)
 		return err
 	}
 	return nil
 }
 
+// persist writes the responses that belong in CacheFile to w.  Responses rejected
+// by PersistToFile are dropped from a copy of Cache rather than from Cache itself,
+// so requests made during a save aren't affected by it.
+func (c *Client) persist(w io.Writer) error {
+	var buf bytes.Buffer
+	err := c.Cache.Save(&buf)
+	if err != nil {
+		return err
+	}
+
+	volatile := c.volatileKeys()
+	if len(volatile) == 0 {
+		_, err = buf.WriteTo(w)
+		return err
+	}
+	saved := cache.New(cacheExpiration, 0)
+	err = saved.Load(&buf)
+	if err != nil {
+		return err
+	}
+	for _, key := range volatile {
+		saved.Delete(key)
+	}
+	return saved.Save(w)
+}
+
+// setVolatile caches body under key for ttl, marking it to be left out of
+// CacheFile.  The mark and the response are set together under the lock
+// volatileKeys takes, so a save can't see one without the other.
+func (c *Client) setVolatile(key string, body []byte, ttl time.Duration) {
+	st := c.shared()
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if st.volatile == nil {
+		st.volatile = make(map[string]bool)
+	}
+	st.volatile[key] = true
+	c.Cache.Set(key, body, ttl)
+}
+
+// volatileKeys returns the keys marked by setVolatile, forgetting those no longer
+// in Cache.
+func (c *Client) volatileKeys() []string {
+	st := c.shared()
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	keys := make([]string, 0, len(st.volatile))
+	for key := range st.volatile {
+		if _, found := c.Cache.Get(key); !found {
+			delete(st.volatile, key)
+			continue
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Reset empties the in-memory cache and the index of decoded shows, leaving the
+// client's configuration and CacheFile on disk untouched.  StartAutoSave won't
+// write the empty cache over CacheFile until something new is cached.
+func (c *Client) Reset() {
+	st := c.shared()
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	c.Cache.Flush()
+	st.known = nil
+	st.volatile = nil
+	atomic.StoreInt32(&st.dirty, 0)
+}
+
 // FindShow searches tvmaze for showname, and returns it as a Show if a match is found.
+// An error wrapping ErrNotFound is returned if no candidate matches.  The show is
+// as returned by the search, which may omit fields of the full show from
+// /shows/{id}, unless FullShow is set.
+//
+// Titles are compared as normalized by NormalizeTitle, prefixes included, so
+// case, punctuation and a leading "The" don't matter: with Region set, "Office"
+// matches a "The Office" from that region on the first pass.
 func (c *Client) FindShow(showname string) (Show, error) {
 	candidates, err := c.GetShow(showname)
 	if err != nil {
@@ -75,20 +340,63 @@ func (c *Client) FindShow(showname string) (Show, error) {
 		for _, cand := range candidates {
 			show := cand.Show
 			if c.Region != "" && retry < 1 {
-				if c.Region == show.Network.Country.Code && strings.HasPrefix(show.Name, showname) {
-					return show, nil
+				if c.Region == show.Network.Country.Code && titleHasPrefix(show.Name, showname) {
+					return c.fullShow(show)
 				}
 			} else {
-				if strings.ToLower(show.Name) == strings.ToLower(showname) {
-					return show, nil
-				} else if retry > 0 && strings.HasPrefix(show.Name, showname) {
-					return show, nil
+				if TitlesEqual(show.Name, showname) {
+					return c.fullShow(show)
+				} else if retry > 0 && titleHasPrefix(show.Name, showname) {
+					return c.fullShow(show)
 				}
 			}
 		}
 	}
 
-	return Show{}, errors.New("Failed to match show in tvmaze!")
+	return Show{}, fmt.Errorf("no tvmaze show matches %q: %w", showname, ErrNotFound)
+}
+
+// fullShow returns the full show for show, a search result, if FullShow is set.
+func (c *Client) fullShow(show Show) (Show, error) {
+	if !c.FullShow {
+		return show, nil
+	}
+	return c.GetShowByID(show.ID)
+}
+
+// FindShowEpisodes finds the show named name, as FindShow does, and returns it
+// along with its episodes.
+func (c *Client) FindShowEpisodes(name string) (Show, []Episode, error) {
+	show, err := c.FindShow(name)
+	if err != nil {
+		return Show{}, nil, err
+	}
+	episodes, err := c.GetEpisodes(show.ID)
+	if err != nil {
+		return Show{}, nil, err
+	}
+	return show, episodes, nil
+}
+
+// MostPopularCandidate searches tvmaze for q, and returns the candidate with the
+// highest Weight, tvmaze's popularity measure, rather than the best text match.
+// Ties go to the better text match.  ErrNotFound is returned if nothing matched.
+func (c *Client) MostPopularCandidate(q string) (Show, error) {
+	candidates, err := c.GetShow(q)
+	if err != nil {
+		return Show{}, err
+	}
+	if len(candidates) == 0 {
+		return Show{}, ErrNotFound
+	}
+
+	best := candidates[0].Show
+	for _, cand := range candidates[1:] {
+		if cand.Show.Weight > best.Weight {
+			best = cand.Show
+		}
+	}
+	return best, nil
 }
 
 // GetShow queries tvmaze for show, and returns Candidates that may be a match.
@@ -96,11 +404,16 @@ func (c *Client) GetShow(show string) ([]Candidate, error) {
 	route := "/search/shows"
 	uri, err := url.Parse(c.BaseURI + route)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
+	}
+
+	normalize := c.QueryNormalizer
+	if normalize == nil {
+		normalize = NormalizeQuery
 	}
 
 	query := url.Values{}
-	query.Add("q", show)
+	query.Add("q", normalize(show))
 	uri.RawQuery = query.Encode()
 
 	var candidates []Candidate
@@ -109,29 +422,79 @@ func (c *Client) GetShow(show string) ([]Candidate, error) {
 		return nil, err
 	}
 
-	err = json.Unmarshal(jsondata, &candidates)
+	err = decodeJSON(route, jsondata, &candidates)
 	if err != nil {
 		return nil, err
 	}
+	for _, cand := range candidates {
+		c.remember(cand.Show)
+	}
 
 	return candidates, nil
 }
 
+// GetShowByID queries tvmaze for the show with id.
+func (c *Client) GetShowByID(id int64) (Show, error) {
+	return c.getShowByID(context.Background(), id)
+}
+
+func (c *Client) getShowByID(ctx context.Context, id int64) (Show, error) {
+	show, _, err := c.getShowByIDRaw(ctx, id)
+	return show, err
+}
+
+// GetShowByIDRaw is like GetShowByID, but also returns the response body exactly
+// as tvmaze sent it, or as it was cached.
+func (c *Client) GetShowByIDRaw(id int64) (Show, []byte, error) {
+	return c.getShowByIDRaw(context.Background(), id)
+}
+
+func (c *Client) getShowByIDRaw(ctx context.Context, id int64) (Show, []byte, error) {
+	route := fmt.Sprintf("/shows/%d", id)
+	uri, err := url.Parse(c.BaseURI + route)
+	if err != nil {
+		return Show{}, nil, err
+	}
+
+	jsondata, err := c.GoContext(ctx, uri)
+	if err != nil {
+		return Show{}, nil, err
+	}
+
+	var show Show
+	err = decodeJSON(route, jsondata, &show)
+	if err != nil {
+		return Show{}, nil, err
+	}
+	c.remember(show)
+
+	return show, jsondata, nil
+}
+
+// NormalizeQuery trims q, collapses runs of whitespace, and lowercases it.
+func NormalizeQuery(q string) string {
+	return strings.ToLower(strings.Join(strings.Fields(q), " "))
+}
+
 // GetEpisodes queries tvmaze, and returns a list of Episodes.
 func (c *Client) GetEpisodes(showID int64) ([]Episode, error) {
+	return c.getEpisodes(context.Background(), showID)
+}
+
+func (c *Client) getEpisodes(ctx context.Context, showID int64) ([]Episode, error) {
 	route := fmt.Sprintf("/shows/%d/episodes", showID)
 	uri, err := url.Parse(c.BaseURI + route)
 	if err != nil {
 		return nil, err
 	}
 
-	jsondata, err := c.Go(uri)
+	jsondata, err := c.GoContext(ctx, uri)
 	if err != nil {
 		return nil, err
 	}
 
 	var episodes []Episode
-	err = json.Unmarshal(jsondata, &episodes)
+	err = decodeJSON(route, jsondata, &episodes)
 	if err != nil {
 		return nil, err
 	}
@@ -140,46 +503,200 @@ func (c *Client) GetEpisodes(showID int64) ([]Episode, error) {
 }
 
 // Go does an HTTP GET to tvmaze with the provided uri, and returns the response body.
-// It will cache response if UseCache is true.
+// It will cache response if UseCache is true.  Only 200 OK responses are cached, so
+// a failed request is always attempted again rather than served from the cache.
 func (c *Client) Go(uri *url.URL) ([]byte, error) {
-	data, found := c.Cache.Get(uri.String())
+	return c.GoContext(context.Background(), uri)
+}
+
+// GoQuery does Go for BaseURI + route with the query params, for endpoints
+// without a method of their own.  route must begin with "/", e.g. "/lookup/shows".
+func (c *Client) GoQuery(route string, params url.Values) ([]byte, error) {
+	if !strings.HasPrefix(route, "/") {
+		return nil, fmt.Errorf("route %q doesn't begin with /", route)
+	}
+	uri, err := url.Parse(c.BaseURI + route)
+	if err != nil {
+		return nil, err
+	}
+	uri.RawQuery = params.Encode()
+
+	return c.Go(uri)
+}
+
+// GoContext is like Go, but the HTTP request is bound to ctx.
+func (c *Client) GoContext(ctx context.Context, uri *url.URL) ([]byte, error) {
+	return c.goTTL(ctx, uri, 0)
+}
+
+// goTTL is GoContext, caching the response for ttl rather than the cache's
+// default expiration when ttl isn't 0.
+func (c *Client) goTTL(ctx context.Context, uri *url.URL, ttl time.Duration) ([]byte, error) {
+	key := c.cacheKey(uri)
+	data, found := c.Cache.Get(key)
+	body, ok := data.([]byte)
 
-	if !found || !c.UseCache {
+	if !found || !ok || !c.UseCache {
 		if c.Debug {
-			log.Print("cache miss: " + uri.String() + "\n")
+			c.logger().Printf("cache miss: %s", uri.String())
 		}
-		request, err := http.NewRequest("GET", uri.String(), nil)
+		var err error
+		body, err = c.get(ctx, uri)
 		if err != nil {
 			return nil, err
 		}
-		if c.UserAgent != "" {
-			request.Header.Set("User-Agent", c.UserAgent)
+		if c.PersistToFile == nil || c.PersistToFile(uri) {
+			c.Cache.Set(key, body, ttl)
+			atomic.StoreInt32(&c.shared().dirty, 1)
+		} else {
+			c.setVolatile(key, body, ttl)
 		}
+	} else {
+		if c.Debug {
+			c.logger().Printf("cache hit: %s", uri.String())
+		}
+	}
 
-		resp, err := c.Do(request)
-		if err != nil {
-			return nil, err
+	return body, nil
+}
+
+// cacheKey returns the cache key for the response to uri.
+func (c *Client) cacheKey(uri *url.URL) string {
+	if c.AcceptLanguage == "" {
+		return uri.String()
+	}
+	return uri.String() + "|Accept-Language=" + c.AcceptLanguage
+}
+
+// get does the HTTP GET for GoContext, consulting RetryPolicy after each failed
+// attempt.  The wait between attempts ends early if ctx is done.
+func (c *Client) get(ctx context.Context, uri *url.URL) ([]byte, error) {
+	policy := c.RetryPolicy
+	if policy == nil {
+		policy = c.DefaultRetryPolicy
+	}
+
+	sem := c.semaphore()
+	for attempt := 1; ; attempt++ {
+		if sem != nil {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+		resp, body, err := c.do(ctx, uri)
+		if sem != nil {
+			<-sem
+		}
+		if err == nil && resp.StatusCode == http.StatusOK {
+			return body, nil
 		}
 
-		if resp.StatusCode != 200 {
+		retry, wait := policy(resp, err, attempt)
+		if !retry {
+			if err != nil {
+				return nil, err
+			}
+			if resp.StatusCode == http.StatusNotFound {
+				return nil, ErrNotFound
+			}
 			return nil, errors.New(fmt.Sprintf("Request failed: %s", http.StatusText(resp.StatusCode)))
 		}
 
-		data, err = ioutil.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
-		c.Cache.Set(uri.String(), data, 0)
-	} else {
 		if c.Debug {
-			fmt.Printf("cache hit: %s\n", uri.String())
+			c.logger().Printf("retrying in %s: %s", wait, uri.String())
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
 		}
 	}
+}
+
+// DefaultRetryPolicy is the RetryPolicy used when none is set.  It retries
+// responses of 429 Too Many Requests or 5xx up to MaxRetries times, waiting
+// RetryWait before the first retry and doubling the wait for each one after.
+// Errors making the request are not retried.
+func (c *Client) DefaultRetryPolicy(resp *http.Response, err error, attempt int) (bool, time.Duration) {
+	if err != nil || attempt > c.MaxRetries {
+		return false, 0
+	}
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode < 500 {
+		return false, 0
+	}
 
-	return data.([]byte), nil
+	wait := c.RetryWait
+	if wait <= 0 {
+		wait = time.Second
+	}
+	return true, wait << uint(attempt-1)
+}
+
+// do makes a single GET request for uri.  The response body is read and
+// returned, and resp.Body is replaced so it can be read again.
+func (c *Client) do(ctx context.Context, uri *url.URL) (*http.Response, []byte, error) {
+	request, err := http.NewRequestWithContext(ctx, "GET", uri.String(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	if c.UserAgent != "" {
+		request.Header.Set("User-Agent", c.UserAgent)
+	}
+	if c.AcceptLanguage != "" {
+		request.Header.Set("Accept-Language", c.AcceptLanguage)
+	}
+
+	resp, err := c.Do(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return resp, body, nil
+}
+
+// semaphore returns the channel limiting requests to MaxConcurrentRequests, or
+// nil when there's no limit.
+func (c *Client) semaphore() chan struct{} {
+	st := c.shared()
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if st.sem == nil && c.MaxConcurrentRequests > 0 {
+		st.sem = make(chan struct{}, c.MaxConcurrentRequests)
+	}
+	return st.sem
+}
+
+// now returns c.Now(), or time.Now() when Now is nil.
+func (c *Client) now() time.Time {
+	if c.Now == nil {
+		return time.Now()
+	}
+	return c.Now()
+}
+
+// logger returns c.Logger, or a Logger that discards everything when it's nil.
+func (c *Client) logger() Logger {
+	if c.Logger == nil {
+		return nopLogger{}
+	}
+	return c.Logger
 }
 
 // Episode represents a tv episode.
+//
+// Season, Number and Runtime may be null in the tvmaze response (specials have
+// no number, unaired episodes often have no runtime), in which case they decode
+// to 0.  Use SeasonOrNil, NumberOrNil and RuntimeOrNil to tell null from 0.
 type Episode struct {
 	ID       int64
 	URL      string
@@ -193,6 +710,55 @@ type Episode struct {
 	//Image
 	Summary string
 	Links   Links `json:"_links"`
+
+	nullSeason  bool
+	nullNumber  bool
+	nullRuntime bool
+}
+
+// UnmarshalJSON decodes an Episode, remembering which nullable fields were null.
+func (e *Episode) UnmarshalJSON(data []byte) error {
+	type episode Episode
+	aux := struct {
+		*episode
+		Season  *int
+		Number  *int
+		Runtime *int
+	}{episode: (*episode)(e)}
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+	e.Season, e.nullSeason = derefInt(aux.Season)
+	e.Number, e.nullNumber = derefInt(aux.Number)
+	e.Runtime, e.nullRuntime = derefInt(aux.Runtime)
+	return nil
+}
+
+// MarshalJSON encodes an Episode, writing null for nullable fields that were null.
+func (e Episode) MarshalJSON() ([]byte, error) {
+	type episode Episode
+	return json.Marshal(struct {
+		episode
+		Season  *int
+		Number  *int
+		Runtime *int
+	}{episode(e), e.SeasonOrNil(), e.NumberOrNil(), e.RuntimeOrNil()})
+}
+
+// SeasonOrNil returns the episode season, or nil if tvmaze had none.
+func (e Episode) SeasonOrNil() *int {
+	return intOrNil(e.Season, e.nullSeason)
+}
+
+// NumberOrNil returns the episode number, or nil if tvmaze had none.
+func (e Episode) NumberOrNil() *int {
+	return intOrNil(e.Number, e.nullNumber)
+}
+
+// RuntimeOrNil returns the episode runtime in minutes, or nil if tvmaze had none.
+func (e Episode) RuntimeOrNil() *int {
+	return intOrNil(e.Runtime, e.nullRuntime)
 }
 
 // Candidate represents a search candidate.
@@ -202,32 +768,122 @@ type Candidate struct {
 }
 
 // Show represents a tv show.
+//
+// Runtime and Rating.Average may be null in the tvmaze response, in which case
+// they decode to 0.  Use RuntimeOrNil and Rating.AverageOrNil to tell null from 0.
 type Show struct {
-	ID        int64
-	URL       string
-	Name      string
-	Type      string
-	Language  string
-	Genres    []string
-	Status    string
-	Runtime   int
-	Premiered string
-	Schedule  Schedule
-	Rating    Rating
-	Weight    int
-	Network   Network
-	//WebChannel
-	Externals External
-	Image     Image
-	Summary   string
-	Updated   int64
-	Links     Links
+	ID         int64
+	URL        string
+	Name       string
+	Type       string
+	Language   string
+	Genres     []string
+	Status     string
+	Runtime    int
+	Premiered  string
+	Schedule   Schedule
+	Rating     Rating
+	Weight     int
+	Network    Network
+	WebChannel WebChannel
+	Externals  External
+	Image      Image
+	Summary    string
+	Updated    int64
+	Links      Links
+
+	nullRuntime bool
+}
+
+// UnmarshalJSON decodes a Show, remembering which nullable fields were null.
+func (s *Show) UnmarshalJSON(data []byte) error {
+	type show Show
+	aux := struct {
+		*show
+		Runtime *int
+	}{show: (*show)(s)}
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+	s.Runtime, s.nullRuntime = derefInt(aux.Runtime)
+	return nil
+}
+
+// MarshalJSON encodes a Show, writing null for nullable fields that were null.
+func (s Show) MarshalJSON() ([]byte, error) {
+	type show Show
+	return json.Marshal(struct {
+		show
+		Runtime *int
+	}{show(s), s.RuntimeOrNil()})
+}
+
+// RuntimeOrNil returns the show runtime in minutes, or nil if tvmaze had none.
+func (s Show) RuntimeOrNil() *int {
+	return intOrNil(s.Runtime, s.nullRuntime)
+}
+
+// APIURL returns the tvmaze API URL for the show, e.g. Client.BaseURI + "/shows/1".
+func (s Show) APIURL(baseURI string) string {
+	return fmt.Sprintf("%s/shows/%d", strings.TrimRight(baseURI, "/"), s.ID)
+}
+
+// WebURL returns the URL of the show's page on the tvmaze website.
+func (s Show) WebURL() string {
+	return s.URL
+}
+
+// PremieredYear returns the year the show premiered, or 0 if it hasn't.
+func (s Show) PremieredYear() int {
+	premiered, err := time.Parse("2006-01-02", s.Premiered)
+	if err != nil {
+		return 0
+	}
+	return premiered.Year()
+}
+
+// IsStreaming reports whether the show is released by a streaming service: it
+// has a web channel, and no network.
+func (s Show) IsStreaming() bool {
+	return s.Network.ID == 0 && s.WebChannel.ID != 0
+}
+
+// IsBroadcast reports whether the show airs on a tv network.  A show with both a
+// network and a web channel is considered broadcast.
+func (s Show) IsBroadcast() bool {
+	return s.Network.ID != 0
+}
+
+// AiringName returns the name of the show's web channel, or of its network when
+// it has no web channel.  A show with both has usually moved from broadcast to
+// streaming, such as a revival, so the web channel is taken as the current one.
+func (s Show) AiringName() string {
+	if s.WebChannel.ID != 0 {
+		return s.WebChannel.Name
+	}
+	return s.Network.Name
+}
+
+// PrimaryCountry returns the country of the show's web channel, or of its
+// network when it has no web channel, preferring the web channel as AiringName
+// does.  Web channels released worldwide have no country, in which case the
+// network's country is used if there is one.
+func (s Show) PrimaryCountry() Country {
+	if s.WebChannel.ID != 0 && s.WebChannel.Country.Code != "" {
+		return s.WebChannel.Country
+	}
+	if s.Network.ID != 0 {
+		return s.Network.Country
+	}
+	return s.WebChannel.Country
 }
 
 // Links represents Episode links.
 type Links struct {
 	Self            Link
 	PreviousEpisode Link
+	Show            Link
 }
 
 // Link represents a uri link.
@@ -245,6 +901,7 @@ type Image struct {
 type External struct {
 	TVRage  int64
 	TheTVDB int64
+	IMDB    string
 }
 
 // Schedule represents a Show schedule.
@@ -256,6 +913,46 @@ type Schedule struct {
 // Rating represents a tv show rating.
 type Rating struct {
 	Average float64
+	// Count is the number of votes behind Average.  tvmaze doesn't currently
+	// publish it, so it's nil unless the response includes a count.
+	Count *int
+
+	nullAverage bool
+}
+
+// UnmarshalJSON decodes a Rating, remembering whether the average was null.
+func (r *Rating) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		Average *float64
+		Count   *int
+	}
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+	r.Count = aux.Count
+	r.Average, r.nullAverage = 0, aux.Average == nil
+	if aux.Average != nil {
+		r.Average = *aux.Average
+	}
+	return nil
+}
+
+// MarshalJSON encodes a Rating, writing a null average for an unrated show.
+func (r Rating) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Average *float64
+		Count   *int `json:",omitempty"`
+	}{r.AverageOrNil(), r.Count})
+}
+
+// AverageOrNil returns the average rating, or nil if the show is unrated.
+func (r Rating) AverageOrNil() *float64 {
+	if r.nullAverage {
+		return nil
+	}
+	avg := r.Average
+	return &avg
 }
 
 // Network represents the tv network airing the show.
@@ -265,9 +962,32 @@ type Network struct {
 	Country Country
 }
 
+// WebChannel represents the streaming service airing the show.
+type WebChannel struct {
+	ID      int
+	Name    string
+	Country Country
+}
+
 // Country represents the country the show aired in.
 type Country struct {
 	Name     string
 	Code     string
 	TimeZone string
 }
+
+// derefInt returns the value of i, and whether i was nil.
+func derefInt(i *int) (int, bool) {
+	if i == nil {
+		return 0, true
+	}
+	return *i, false
+}
+
+// intOrNil returns nil if null is true, otherwise a pointer to a copy of i.
+func intOrNil(i int, null bool) *int {
+	if null {
+		return nil
+	}
+	return &i
+}
@@ -0,0 +1,370 @@
+package tvmaze
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	cache "github.com/robfig/go-cache"
+)
+
+// newTestClient returns a client making its requests to a test server running h.
+func newTestClient(t *testing.T, h http.HandlerFunc) *Client {
+	t.Helper()
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	return &Client{
+		BaseURI:  srv.URL,
+		Cache:    cache.New(time.Hour, 0),
+		UseCache: true,
+		Client:   srv.Client(),
+	}
+}
+
+func TestCloneSharesCacheState(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 1, "name": "The Office"}`))
+	})
+	c.CacheFile = filepath.Join(t.TempDir(), "cache")
+	c.PersistToFile = func(uri *url.URL) bool { return false }
+
+	clone := c.Clone()
+	if _, err := clone.GetShowByID(1); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.KnownShow(1); !ok {
+		t.Error("show decoded by the clone isn't known to the original")
+	}
+
+	if err := c.WriteCache(); err != nil {
+		t.Fatal(err)
+	}
+	saved := cache.New(0, 0)
+	if err := saved.LoadFile(c.CacheFile); err != nil {
+		t.Fatal(err)
+	}
+	if _, found := saved.Get(c.BaseURI + "/shows/1"); found {
+		t.Error("response the clone's PersistToFile rejected was written by the original")
+	}
+}
+
+func TestWriteCacheLeavesVolatileResponsesCached(t *testing.T) {
+	hits := 0
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.Write([]byte(`{"1": 1500000000}`))
+	})
+	c.CacheFile = filepath.Join(t.TempDir(), "cache")
+	c.PersistToFile = func(uri *url.URL) bool { return uri.Path != "/updates/shows" }
+
+	uri, err := url.Parse(c.BaseURI + "/updates/shows")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.goTTL(context.Background(), uri, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.WriteCache(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Go(uri); err != nil {
+		t.Fatal(err)
+	}
+	if hits != 1 {
+		t.Errorf("got %d requests, want 1: WriteCache dropped the cached response", hits)
+	}
+
+	saved := cache.New(0, 0)
+	if err := saved.LoadFile(c.CacheFile); err != nil {
+		t.Fatal(err)
+	}
+	if _, found := saved.Get(uri.String()); found {
+		t.Error("response rejected by PersistToFile was written")
+	}
+}
+
+func TestWriteCacheVolatileIgnoresClientNow(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"1": 1500000000}`))
+	})
+	c.CacheFile = filepath.Join(t.TempDir(), "cache")
+	c.PersistToFile = func(uri *url.URL) bool { return uri.Path != "/updates/shows" }
+	now := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	c.Now = func() time.Time { return now }
+
+	if _, err := c.GetShowUpdates(""); err != nil {
+		t.Fatal(err)
+	}
+	now = now.Add(24 * time.Hour)
+	if err := c.WriteCache(); err != nil {
+		t.Fatal(err)
+	}
+
+	saved := cache.New(0, 0)
+	if err := saved.LoadFile(c.CacheFile); err != nil {
+		t.Fatal(err)
+	}
+	uri, err := c.updatesURI("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, found := saved.Get(uri.String()); found {
+		t.Error("response rejected by PersistToFile was written once Now moved on")
+	}
+}
+
+func TestCloseStopsSweepForClones(t *testing.T) {
+	c, err := NewClient(filepath.Join(t.TempDir(), "cache"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	clone := c.Clone()
+	if err := clone.Close(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-c.shared().stopSweep:
+	default:
+		t.Error("closing a clone didn't stop the sweep")
+	}
+	if err := c.Close(); err != nil {
+		t.Errorf("second Close: %v", err)
+	}
+}
+
+func TestSweepStops(t *testing.T) {
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		sweep(cache.New(time.Millisecond, 0), time.Millisecond, stop)
+		close(done)
+	}()
+	close(stop)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("sweep didn't return once stopped")
+	}
+}
+
+func TestShowWithNetworkAndWebChannel(t *testing.T) {
+	show := Show{
+		Network:    Network{ID: 1, Name: "NBC", Country: Country{Code: "US"}},
+		WebChannel: WebChannel{ID: 2, Name: "Netflix", Country: Country{Code: "GB"}},
+	}
+	if got := show.AiringName(); got != "Netflix" {
+		t.Errorf("AiringName() = %q, want Netflix", got)
+	}
+	if got := show.PrimaryCountry().Code; got != "GB" {
+		t.Errorf("PrimaryCountry().Code = %q, want GB", got)
+	}
+	if show.IsStreaming() {
+		t.Error("IsStreaming() = true, want false")
+	}
+	if !show.IsBroadcast() {
+		t.Error("IsBroadcast() = false, want true")
+	}
+
+	show.WebChannel.Country = Country{}
+	if got := show.PrimaryCountry().Code; got != "US" {
+		t.Errorf("PrimaryCountry().Code of a worldwide web channel = %q, want US", got)
+	}
+}
+
+func TestFindShowNotFound(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"score": 1, "show": {"id": 1, "name": "Lost"}}]`))
+	})
+	_, err := c.FindShow("Dallas")
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("got error %v, want one wrapping ErrNotFound", err)
+	}
+	if !strings.Contains(err.Error(), `"Dallas"`) {
+		t.Errorf("error %q doesn't name the show", err)
+	}
+}
+
+func TestGetShowNormalizesQuery(t *testing.T) {
+	hits := 0
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.Write([]byte(`[]`))
+	})
+	for _, q := range []string{"the office", "The  Office "} {
+		if _, err := c.GetShow(q); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if hits != 1 {
+		t.Errorf("got %d requests, want 1 shared cache entry", hits)
+	}
+
+	c.QueryNormalizer = func(q string) string { return q }
+	if _, err := c.GetShow("The  Office "); err != nil {
+		t.Fatal(err)
+	}
+	if hits != 2 {
+		t.Errorf("got %d requests, want QueryNormalizer to bypass the normalized entry", hits)
+	}
+}
+
+func TestGoContextCancelledDuringBackoff(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	c.MaxRetries = 3
+	c.RetryWait = time.Hour
+
+	uri, err := url.Parse(c.BaseURI + "/shows/1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c.RetryPolicy = func(resp *http.Response, err error, attempt int) (bool, time.Duration) {
+		// Cancel as the backoff begins.
+		defer cancel()
+		return c.DefaultRetryPolicy(resp, err, attempt)
+	}
+
+	errc := make(chan error, 1)
+	go func() {
+		_, err := c.GoContext(ctx, uri)
+		errc <- err
+	}()
+	select {
+	case err := <-errc:
+		if err != context.Canceled {
+			t.Errorf("got error %v, want %v", err, context.Canceled)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("GoContext slept through its backoff after ctx was cancelled")
+	}
+}
+
+func TestRetryPolicyFailFast(t *testing.T) {
+	hits := 0
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	c.MaxRetries = 3
+	var calls []int
+	c.RetryPolicy = func(resp *http.Response, err error, attempt int) (bool, time.Duration) {
+		if resp == nil || resp.StatusCode != http.StatusServiceUnavailable {
+			t.Errorf("policy got response %v, error %v, want a 503 response", resp, err)
+		}
+		calls = append(calls, attempt)
+		return false, 0
+	}
+
+	if _, err := c.GetShowByID(1); err == nil {
+		t.Fatal("GetShowByID succeeded against a failing server")
+	}
+	if hits != 1 {
+		t.Errorf("got %d requests, want 1", hits)
+	}
+	if len(calls) != 1 || calls[0] != 1 {
+		t.Errorf("policy called with attempts %v, want [1]", calls)
+	}
+}
+
+func TestFailedResponseNotCached(t *testing.T) {
+	hits := 0
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		if hits == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"name": "Internal Server Error"}`))
+			return
+		}
+		w.Write([]byte(`{"id": 1, "name": "The Office"}`))
+	})
+
+	if _, err := c.GetShowByID(1); err == nil {
+		t.Fatal("GetShowByID succeeded against a failing server")
+	}
+	show, err := c.GetShowByID(1)
+	if err != nil {
+		t.Fatalf("second GetShowByID: %v", err)
+	}
+	if show.Name != "The Office" {
+		t.Errorf("got show %q, want the error body to have been left uncached", show.Name)
+	}
+	if hits != 2 {
+		t.Errorf("got %d requests, want the failed one made again", hits)
+	}
+}
+
+func TestShowStreamingOrBroadcast(t *testing.T) {
+	nbc := Network{ID: 1, Name: "NBC"}
+	netflix := WebChannel{ID: 1, Name: "Netflix"}
+	tests := []struct {
+		name                 string
+		show                 Show
+		streaming, broadcast bool
+	}{
+		{"broadcast", Show{Network: nbc}, false, true},
+		{"streaming", Show{WebChannel: netflix}, true, false},
+		{"both", Show{Network: nbc, WebChannel: netflix}, false, true},
+		{"neither", Show{}, false, false},
+	}
+	for _, tt := range tests {
+		if got := tt.show.IsStreaming(); got != tt.streaming {
+			t.Errorf("%s: IsStreaming() = %v, want %v", tt.name, got, tt.streaming)
+		}
+		if got := tt.show.IsBroadcast(); got != tt.broadcast {
+			t.Errorf("%s: IsBroadcast() = %v, want %v", tt.name, got, tt.broadcast)
+		}
+	}
+}
+
+func TestMaxConcurrentRequests(t *testing.T) {
+	const limit = 3
+	var inFlight, peak int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		w.Write([]byte(`{}`))
+	})
+	c.MaxConcurrentRequests = limit
+	clone := c.Clone()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4*limit; i++ {
+		client := c
+		if i%2 == 1 {
+			client = clone
+		}
+		wg.Add(1)
+		go func(client *Client, id int64) {
+			defer wg.Done()
+			if _, err := client.GetShowByID(id); err != nil {
+				t.Error(err)
+			}
+		}(client, int64(i))
+	}
+	wg.Wait()
+
+	if peak > limit {
+		t.Errorf("%d requests were in flight at once, want at most %d", peak, limit)
+	}
+	if peak < 2 {
+		t.Errorf("at most %d request was in flight at once, want requests made concurrently", peak)
+	}
+}
@@ -0,0 +1,88 @@
+package tvmaze
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// defaultUpdatesTTL is how long GetShowUpdates responses are cached when
+// UpdatesTTL isn't set.
+const defaultUpdatesTTL = 5 * time.Minute
+
+// updatesPeriods are the values of since accepted by GetShowUpdates.
+var updatesPeriods = []string{"", "day", "week", "month"}
+
+// GetShowUpdates queries tvmaze for the time each show was last updated, as a
+// map of show ID to unix timestamp.  since may be "day", "week" or "month" to
+// only include shows updated within that period, or "" for every show.
+// Responses are cached for UpdatesTTL, so repeated calls during a sync reuse
+// them.
+func (c *Client) GetShowUpdates(since string) (map[int64]int64, error) {
+	uri, err := c.updatesURI(since)
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := c.UpdatesTTL
+	if ttl <= 0 {
+		ttl = defaultUpdatesTTL
+	}
+	jsondata, err := c.goTTL(context.Background(), uri, ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	var updates map[int64]int64
+	err = decodeJSON(uri.Path, jsondata, &updates)
+	if err != nil {
+		return nil, err
+	}
+
+	return updates, nil
+}
+
+// IsShowStale reports whether tvmaze has updated the show with id since
+// cachedUpdated, the Show.Updated of the caller's copy.  It consults the full
+// updates feed from GetShowUpdates, so checking many shows within UpdatesTTL
+// costs a single request.  An error wrapping ErrNotFound is returned if the show
+// isn't in the feed.
+func (c *Client) IsShowStale(showID int64, cachedUpdated int64) (bool, error) {
+	updates, err := c.GetShowUpdates("")
+	if err != nil {
+		return false, err
+	}
+	updated, ok := updates[showID]
+	if !ok {
+		return false, fmt.Errorf("show %d isn't in the updates feed: %w", showID, ErrNotFound)
+	}
+	return updated > cachedUpdated, nil
+}
+
+// InvalidateUpdates removes every cached GetShowUpdates response, so the next
+// call fetches a fresh one.
+func (c *Client) InvalidateUpdates() {
+	for _, since := range updatesPeriods {
+		uri, err := c.updatesURI(since)
+		if err != nil {
+			continue
+		}
+		c.Cache.Delete(c.cacheKey(uri))
+	}
+}
+
+func (c *Client) updatesURI(since string) (*url.URL, error) {
+	route := "/updates/shows"
+	uri, err := url.Parse(c.BaseURI + route)
+	if err != nil {
+		return nil, err
+	}
+
+	if since != "" {
+		query := url.Values{}
+		query.Add("since", since)
+		uri.RawQuery = query.Encode()
+	}
+	return uri, nil
+}
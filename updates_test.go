@@ -0,0 +1,46 @@
+package tvmaze
+
+import (
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	cache "github.com/robfig/go-cache"
+)
+
+func TestGetShowUpdatesCached(t *testing.T) {
+	hits := 0
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.Write([]byte(`{"1": 1500000000, "2": 1600000000}`))
+	})
+	c.CacheFile = filepath.Join(t.TempDir(), "cache")
+
+	for i := 0; i < 2; i++ {
+		updates, err := c.GetShowUpdates("")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if updates[2] != 1600000000 {
+			t.Errorf("got updates %v", updates)
+		}
+	}
+	if hits != 1 {
+		t.Errorf("got %d requests, want 1", hits)
+	}
+
+	if err := c.WriteCache(); err != nil {
+		t.Fatal(err)
+	}
+	saved := cache.New(0, 0)
+	if err := saved.LoadFile(c.CacheFile); err != nil {
+		t.Fatal(err)
+	}
+	uri, err := c.updatesURI("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, found := saved.Get(uri.String()); !found {
+		t.Error("updates response wasn't written with a nil PersistToFile")
+	}
+}